@@ -0,0 +1,210 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	missingAliasFor  = "Missing alias for internal type "
+	unexportedType   = "Exported API refers to unexported type "
+	unimportableType = "Exported API refers to a type in another module's internal package, which applications can't import: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO018", helpInternalPackages, checkTypeReferences))
+}
+
+// navigatorTypeRgx captures the package and type name of navigators like "<azblob/internal.Foo>"
+var navigatorTypeRgx = regexp.MustCompile(`<([^>]+)\.([^.>]+)>`)
+
+// typeRef is a reference to a type in a package's API
+type typeRef struct {
+	// embedded indicates the reference is an embedded type
+	embedded bool
+	// id is the LineID of the declaration referring to the type
+	id string
+	// t is the referring type expression, including navigators e.g. "[]*<azblob.Foo>Foo"
+	t string
+	// typeParams names the declaration's type parameters, which look like types in t
+	typeParams []string
+}
+
+// typeRefs returns the type references in a package's exported API: func params and results,
+// struct fields and embedded types, interface methods and embedded interfaces, and the
+// underlying types of defined types. It omits aliases for types of the module's reviewed
+// packages, and their methods, because rules check those at their definitions.
+func (m *Module) typeRefs(p *Pkg) []typeRef {
+	c := p.c
+	refs := []typeRef{}
+	addFunc := func(id string, fn Func, typeParams []string) {
+		typeParams = append(append(typeParams, fn.typeParamNames...), fn.receiverTypeParams...)
+		for _, t := range append(append([]string{}, fn.paramTypes...), fn.Returns...) {
+			refs = append(refs, typeRef{id: id, t: t, typeParams: typeParams})
+		}
+	}
+	for _, fn := range c.Funcs {
+		if fn.Exported() && !m.reviewedAlias(p, fn.receiverBaseType) {
+			addFunc(fn.ID(), fn, nil)
+		}
+	}
+	for _, in := range c.Interfaces {
+		if !in.Exported() || m.reviewedAlias(p, in.Name()) {
+			continue
+		}
+		for _, t := range in.embeddedInterfaces {
+			refs = append(refs, typeRef{id: in.ID(), t: t})
+		}
+		for name, fn := range in.methods {
+			if fn.Exported() {
+				addFunc(in.ID()+"-"+name, fn, nil)
+			}
+		}
+	}
+	for _, t := range c.SimpleTypes {
+		if t.Exported() && !m.reviewedAlias(p, t.Name()) {
+			refs = append(refs, typeRef{id: t.ID(), t: t.underlyingType})
+		}
+	}
+	for _, s := range c.Structs {
+		if s.Exported() && !m.reviewedAlias(p, s.Name()) {
+			refs = append(refs, s.typeRefs(nil)...)
+		}
+	}
+	return refs
+}
+
+// typeRefs returns references to the types of the struct's exported fields and embedded types
+func (s Struct) typeRefs(typeParams []string) []typeRef {
+	for _, tp := range s.typeParams {
+		typeParams = append(typeParams, strings.Fields(tp)[0])
+	}
+	refs := []typeRef{}
+	for _, t := range s.embeddedTypes {
+		refs = append(refs, typeRef{embedded: true, id: s.id, t: t, typeParams: typeParams})
+	}
+	for name, t := range s.fields {
+		if !exportedFieldRgx.MatchString(name) {
+			continue
+		}
+		if nested, ok := s.nested[name]; ok {
+			refs = append(refs, nested.typeRefs(typeParams)...)
+			continue
+		}
+		refs = append(refs, typeRef{id: s.id + "-" + name, t: t, typeParams: typeParams})
+	}
+	return refs
+}
+
+// typeNames returns the identifiers in type positions of the type expression t, which excludes the
+// names of parameters in func types. It returns nil when t isn't a valid expression.
+func typeNames(t string) map[string]bool {
+	expr, err := parser.ParseExpr(strings.TrimPrefix(stripNavigators(t), "..."))
+	if err != nil {
+		return nil
+	}
+	names := map[string]bool{}
+	var collect func(ast.Node) bool
+	collect = func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.Field:
+			ast.Inspect(x.Type, collect)
+			return false
+		case *ast.Ident:
+			names[x.Name] = true
+		}
+		return true
+	}
+	ast.Inspect(expr, collect)
+	return names
+}
+
+// publicTypes returns the qualified names, as they appear in navigators, of the types a module's
+// reviewed packages export either by defining them or aliasing them
+func publicTypes(m *Module) map[string]bool {
+	public := map[string]bool{}
+	for _, p := range m.reviewedPackages() {
+		for name := range p.c.Structs {
+			public[p.Name()+"."+name] = true
+		}
+		for name := range p.c.Interfaces {
+			public[p.Name()+"."+name] = true
+		}
+		for name := range p.c.SimpleTypes {
+			public[p.Name()+"."+name] = true
+		}
+		for _, ta := range p.TypeAliases {
+			if after, found := strings.CutPrefix(ta.QualifiedName, p.modulePath); found && token.IsExported(ta.Name) {
+				public[path.Base(p.modulePath)+after] = true
+			}
+		}
+	}
+	return public
+}
+
+// checkTypeReferences flags exported API referring to unexported types, to types defined in the
+// module's internal packages which no public package aliases, or to types defined in other
+// modules' internal packages, which applications can't name at all
+func checkTypeReferences(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	public := publicTypes(m)
+	for _, p := range m.reviewedPackages() {
+		seen := map[string]bool{}
+		add := func(id, text string) {
+			if seen[id+text] {
+				return
+			}
+			seen[id+text] = true
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelError,
+				TargetID: id,
+				Text:     text,
+			})
+		}
+		for _, ref := range m.typeRefs(p) {
+			// types of the module have navigators, unlike those of other modules
+			for _, sel := range selectors(ref.t) {
+				ip := m.importPath(p, sel.qualifier)
+				if ip == "" || m.contains(ip) || !strings.Contains(ip+"/", "/internal/") {
+					continue
+				}
+				add(ref.id, unimportableType+sel.qualifier+"."+sel.name+" ("+ip+")")
+			}
+			names := typeNames(ref.t)
+			for _, match := range navigatorTypeRgx.FindAllStringSubmatch(ref.t, -1) {
+				pkg, name := match[1], match[2]
+				if types.Universe.Lookup(name) != nil || slices.Contains(ref.typeParams, name) {
+					continue
+				}
+				if names != nil && !names[name] {
+					// name is a func type's parameter name, not a type
+					continue
+				}
+				text := ""
+				if !token.IsExported(name) {
+					if ref.embedded {
+						// checkEmbeddedStructs covers these
+						continue
+					}
+					text = unexportedType + name
+				} else if strings.Contains("/"+pkg+"/", "/internal/") && !public[pkg+"."+name] {
+					text = missingAliasFor + name
+				}
+				if text != "" {
+					add(ref.id, text)
+				}
+			}
+		}
+	}
+	return diagnostics
+}
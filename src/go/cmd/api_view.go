@@ -11,23 +11,24 @@ import (
 	"strings"
 )
 
-// CreateAPIView generates the output file that the API view tool uses.
-func CreateAPIView(pkgDir, outputDir string) error {
-	review, err := createReview(pkgDir)
+// CreateAPIView generates the output file that the API view tool uses. Options may be nil.
+func CreateAPIView(pkgDir, outputDir string, o *ReviewOptions) error {
+	review, err := createReview(pkgDir, o)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	return writeAPIView(review, outputDir)
+}
+
+// writeAPIView writes a review to outputDir in the file the API view tool uses e.g. "azblob.json"
+func writeAPIView(review CodeFile, outputDir string) error {
 	filename := filepath.Join(outputDir, review.Name+".json")
 	file, _ := json.MarshalIndent(review, "", " ")
-	err = os.WriteFile(filename, file, 0644)
-	if err != nil {
-		return err
-	}
-	return nil
+	return os.WriteFile(filename, file, 0644)
 }
 
-func createReview(pkgDir string) (CodeFile, error) {
-	r, err := NewReview(pkgDir)
+func createReview(pkgDir string, o *ReviewOptions) (CodeFile, error) {
+	r, err := NewReview(pkgDir, o)
 	if err != nil {
 		return CodeFile{}, err
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,7 +26,7 @@ func TestOutput(t *testing.T) {
 	// normalizing line endings prevents flakiness due to git's handling of CRLF
 	expected = bytes.ReplaceAll(expected, []byte("\r\n"), []byte("\n"))
 
-	review, err := createReview(filepath.Dir(f))
+	review, err := createReview(filepath.Dir(f), nil)
 	require.NoError(t, err)
 	actual, err := json.MarshalIndent(review, "", "  ")
 	require.NoError(t, err)
@@ -85,7 +86,7 @@ func TestMultiModule(t *testing.T) {
 		"testdata/test_multi_module/A/B",
 	} {
 		t.Run(path, func(t *testing.T) {
-			p, err := createReview(filepath.Clean(path))
+			p, err := createReview(filepath.Clean(path), nil)
 			require.NoError(t, err)
 			require.Equal(t, 1, len(p.Navigation), "review should include only one package")
 			require.Equal(t, filepath.Base(path), p.Navigation[0].Text, "review includes the wrong module")
@@ -94,7 +95,7 @@ func TestMultiModule(t *testing.T) {
 }
 
 func TestSubpackage(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_subpackage"))
+	review, err := createReview(filepath.Clean("testdata/test_subpackage"), nil)
 	require.NoError(t, err)
 	require.Equal(t, "Go", review.Language)
 	require.Equal(t, "test_subpackage", review.Name)
@@ -123,7 +124,7 @@ func TestSubpackage(t *testing.T) {
 }
 
 func TestDiagnostics(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_diagnostics"))
+	review, err := createReview(filepath.Clean("testdata/test_diagnostics"), nil)
 	require.NoError(t, err)
 	require.Equal(t, "Go", review.Language)
 	require.Equal(t, "test_diagnostics", review.Name)
@@ -148,8 +149,96 @@ func TestDiagnostics(t *testing.T) {
 	}
 }
 
+func TestStructTags(t *testing.T) {
+	for _, mode := range []StructTagMode{"", StructTagsHidden, StructTagsSkipDiff, StructTagsShown} {
+		t.Run(string(mode), func(t *testing.T) {
+			review, err := createReview(filepath.Clean("testdata/test_struct_tags"), &ReviewOptions{StructTags: mode})
+			require.NoError(t, err)
+			require.Equal(t, 2, len(review.Diagnostics))
+			for _, diagnostic := range review.Diagnostics {
+				require.Equal(t, CodeDiagnosticLevelWarning, diagnostic.Level)
+				switch target := diagnostic.TargetID; target {
+				case "test_struct_tags.Model-Location":
+					require.Equal(t, missingOmitempty, diagnostic.Text)
+				case "test_struct_tags.Model-ResourceID":
+					require.Equal(t, inconsistentTagName+"resource_id", diagnostic.Text)
+				default:
+					t.Fatal("unexpected target " + target)
+				}
+			}
+			tags := map[string]ReviewToken{}
+			forAll(review.ReviewLines, func(rl ReviewLine) {
+				for _, tk := range rl.Tokens {
+					if tk.Kind == TokenKindStringLiteral {
+						tags[rl.LineID] = tk
+					}
+				}
+			})
+			if mode == StructTagsSkipDiff || mode == StructTagsShown {
+				require.Equal(t, 6, len(tags))
+				tk := tags["test_struct_tags.Model-Name"]
+				require.Equal(t, "`json:\"name,omitempty\" xml:\"Name\"`", tk.Value)
+				require.Equal(t, mode == StructTagsSkipDiff, tk.SkipDiff)
+			} else {
+				require.Empty(t, tags)
+			}
+		})
+	}
+}
+
+func TestSourceLayout(t *testing.T) {
+	for _, test := range []struct {
+		layout          Layout
+		fields, methods []string
+	}{
+		{
+			layout: LayoutAlphabetical,
+			fields: []string{"Exported", "ExportedAsWell", "N"},
+			// methods sort by signature, which includes the receiver
+			methods: []string{"NewStructA", "NewStructAWithString", "MethodNoReturn", "MethodTwoReturns", "UnmarshalJSON", "MarshalJSON", "MethodOneReturn"},
+		},
+		{
+			layout:  LayoutSource,
+			fields:  []string{"Exported", "N", "ExportedAsWell"},
+			methods: []string{"NewStructA", "NewStructAWithString", "MethodNoReturn", "MethodOneReturn", "MethodTwoReturns", "MarshalJSON", "UnmarshalJSON"},
+		},
+	} {
+		t.Run(string(test.layout), func(t *testing.T) {
+			review, err := createReview(filepath.Clean("testdata/test_output"), &ReviewOptions{Layout: test.layout})
+			require.NoError(t, err)
+			var structA ReviewLine
+			searchLines(review.ReviewLines, func(rl ReviewLine) bool {
+				if rl.LineID == "test_output/subpackage.StructA" {
+					structA = rl
+					return true
+				}
+				return false
+			})
+			fields, methods := []string{}, []string{}
+			for _, c := range structA.Children {
+				if len(c.Tokens) == 0 {
+					continue
+				}
+				if c.Tokens[0].Value == "func" {
+					// the method name follows "func" and the receiver, if any
+					for _, tk := range c.Tokens {
+						if strings.HasPrefix(c.LineID, "test_output/subpackage-") && strings.HasSuffix(c.LineID, tk.Value) {
+							methods = append(methods, tk.Value)
+							break
+						}
+					}
+				} else {
+					fields = append(fields, c.Tokens[0].Value)
+				}
+			}
+			require.Equal(t, test.fields, fields)
+			require.Equal(t, test.methods, methods)
+		})
+	}
+}
+
 func TestExternalModule(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_external_module"))
+	review, err := createReview(filepath.Clean("testdata/test_external_module"), nil)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(review.Diagnostics))
 	require.Equal(t, aliasFor+"github.com/Azure/azure-sdk-for-go/sdk/azcore.Policy", review.Diagnostics[0].Text)
@@ -201,7 +290,7 @@ func TestAliasDefinitions(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			p, err := filepath.Abs(test.path)
 			require.NoError(t, err)
-			review, err := createReview(p)
+			review, err := createReview(p, nil)
 			require.NoError(t, err)
 			require.Equal(t, "Go", review.Language)
 			require.Equal(t, 1, len(review.Diagnostics))
@@ -228,7 +317,7 @@ func TestRecursiveAliasDefinitions(t *testing.T) {
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			review, err := createReview(filepath.Clean(test.path))
+			review, err := createReview(filepath.Clean(test.path), nil)
 			require.NoError(t, err)
 			require.Equal(t, "Go", review.Language)
 			require.Equal(t, 2, len(review.Diagnostics))
@@ -243,38 +332,39 @@ func TestRecursiveAliasDefinitions(t *testing.T) {
 }
 
 func TestAliasDiagnostics(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_alias_diagnostics"))
+	review, err := createReview(filepath.Clean("testdata/test_alias_diagnostics"), nil)
 	require.NoError(t, err)
 	require.Equal(t, "Go", review.Language)
 	require.Equal(t, "test_alias_diagnostics", review.Name)
 	require.Equal(t, 6, len(review.Diagnostics))
 	for _, diagnostic := range review.Diagnostics {
-		if diagnostic.TargetID == "test_alias_diagnostics.WidgetValue" {
+		switch diagnostic.TargetID {
+		case "test_alias_diagnostics.Widget":
+			require.Equal(t, CodeDiagnosticLevelInfo, diagnostic.Level)
+			require.Equal(t, aliasFor+"internal.Widget", diagnostic.Text)
+		case "test_alias_diagnostics.WidgetValue":
 			require.Equal(t, CodeDiagnosticLevelInfo, diagnostic.Level)
 			require.Equal(t, aliasFor+"internal.WidgetValue", diagnostic.Text)
-		} else {
-			require.Equal(t, "test_alias_diagnostics.Widget", diagnostic.TargetID)
-			switch diagnostic.Level {
-			case CodeDiagnosticLevelInfo:
-				require.Equal(t, aliasFor+"internal.Widget", diagnostic.Text)
-			case CodeDiagnosticLevelError:
-				switch txt := diagnostic.Text; txt {
-				case missingAliasFor + "WidgetProperties":
-				case missingAliasFor + "WidgetPropertiesP":
-				case missingAliasFor + "WidgetThings":
-				case missingAliasFor + "WidgetThingsP":
-				default:
-					t.Fatalf("unexpected diagnostic text %s", txt)
-				}
-			default:
-				t.Fatalf("unexpected diagnostic level %d", diagnostic.Level)
-			}
+		case "test_alias_diagnostics.Widget-MissingScalar":
+			require.Equal(t, missingAliasFor+"WidgetProperties", diagnostic.Text)
+		case "test_alias_diagnostics.Widget-MissingScalarP":
+			require.Equal(t, missingAliasFor+"WidgetPropertiesP", diagnostic.Text)
+		case "test_alias_diagnostics.Widget-MissingSlice":
+			require.Equal(t, missingAliasFor+"WidgetThings", diagnostic.Text)
+		case "test_alias_diagnostics.Widget-MissingSliceP":
+			require.Equal(t, missingAliasFor+"WidgetThingsP", diagnostic.Text)
+		default:
+			t.Fatalf("unexpected diagnostic for %s", diagnostic.TargetID)
+		}
+		if strings.HasPrefix(diagnostic.Text, missingAliasFor) {
+			require.Equal(t, CodeDiagnosticLevelError, diagnostic.Level)
+			require.Equal(t, "GO018", diagnostic.DiagnosticID)
 		}
 	}
 }
 
 func TestMajorVersion(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_major_version"))
+	review, err := createReview(filepath.Clean("testdata/test_major_version"), nil)
 	require.NoError(t, err)
 	require.Equal(t, "Go", review.Language)
 	require.Equal(t, "test_major_version", review.Name)
@@ -283,7 +373,7 @@ func TestMajorVersion(t *testing.T) {
 }
 
 func TestVars(t *testing.T) {
-	review, err := createReview(filepath.Clean("testdata/test_vars"))
+	review, err := createReview(filepath.Clean("testdata/test_vars"), nil)
 	require.NoError(t, err)
 	require.NotZero(t, review)
 	countSomeChoice := 0
@@ -303,6 +393,207 @@ func TestVars(t *testing.T) {
 	require.True(t, hasHTTPClient)
 }
 
+func TestTypeSetInterface(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_output"), nil)
+	require.NoError(t, err)
+	var number ReviewLine
+	searchLines(review.ReviewLines, func(rl ReviewLine) bool {
+		if rl.LineID == "test_output/subpackage.Number" {
+			number = rl
+			return true
+		}
+		return false
+	})
+	require.NotEmpty(t, number.Children, "missing Number's type set")
+	values := []string{}
+	for _, tk := range number.Children[0].Tokens {
+		values = append(values, tk.Value)
+		if tk.Value == "~" || tk.Value == "|" {
+			require.Equal(t, TokenKindPunctuation, tk.Kind)
+		}
+	}
+	require.Equal(t, []string{"~", "int", "|", "~", "int64", "|", "float64"}, values)
+
+	// an interface's single term may be a defined type or an embedded interface. Both render
+	// as the type's name, however only the defined type makes the interface a constraint.
+	children := map[string][]string{}
+	searchLines(review.ReviewLines, func(rl ReviewLine) bool {
+		switch rl.LineID {
+		case "test_output/subpackage.EmbedsInterface", "test_output/subpackage.Temperature":
+			for _, c := range rl.Children {
+				for _, tk := range c.Tokens {
+					children[rl.LineID] = append(children[rl.LineID], tk.Value)
+				}
+			}
+		}
+		return false
+	})
+	require.Equal(t, map[string][]string{
+		"test_output/subpackage.EmbedsInterface": {"Interface"},
+		"test_output/subpackage.Temperature":     {"Celsius"},
+	}, children)
+
+	constraints := map[string]bool{}
+	for _, nav := range review.Navigation {
+		for _, item := range nav.ChildItems {
+			if (*item.Tags)["Constraint"] == "true" {
+				constraints[item.NavigationID] = true
+			}
+		}
+	}
+	require.Equal(t, map[string]bool{
+		"test_output.Number":                 true,
+		"test_output.Stringish":              true,
+		"test_output/subpackage.Number":      true,
+		"test_output/subpackage.Stringish":   true,
+		"test_output/subpackage.Temperature": true,
+	}, constraints)
+}
+
+func TestGenericReceiver(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_output"), nil)
+	require.NoError(t, err)
+	var pager ReviewLine
+	searchLines(review.ReviewLines, func(rl ReviewLine) bool {
+		if rl.LineID == "test_output/subpackage.Pager" {
+			pager = rl
+			return true
+		}
+		return false
+	})
+	children := map[string]ReviewLine{}
+	for _, c := range pager.Children {
+		children[c.LineID] = c
+	}
+	require.Contains(t, children, "test_output/subpackage-NewPager", "constructor should be grouped with its type")
+	method, ok := children["test_output/subpackage-(p *Pager[T]) NextPage"]
+	require.True(t, ok, "method should be grouped with its type")
+	linked := []string{}
+	for _, tk := range method.Tokens {
+		if tk.NavigateToID == "test_output/subpackage.Pager" {
+			linked = append(linked, tk.Value)
+		}
+	}
+	// the receiver's type, its parameter and the return type T all link to Pager
+	require.Equal(t, []string{"Pager", "T", "T"}, linked)
+}
+
+func TestAliasDiagnosticsNotRepeated(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_output"), nil)
+	require.NoError(t, err)
+	targets := []string{}
+	for _, d := range review.Diagnostics {
+		if d.Text == sealedInterface {
+			targets = append(targets, d.TargetID)
+		}
+	}
+	// test_output.Unimplementable is an alias for this interface, which the review flags only at its definition
+	require.Equal(t, []string{"test_output/subpackage.Unimplementable"}, targets)
+
+	// no rule should flag both an alias in test_output and its definition in test_output/subpackage,
+	// whose LineIDs differ only by package
+	type diagnostic struct{ id, target, text string }
+	seen := map[diagnostic]bool{}
+	for _, d := range review.Diagnostics {
+		seen[diagnostic{d.DiagnosticID, d.TargetID, d.Text}] = true
+	}
+	for d := range seen {
+		// types have LineIDs like "pkg.Name" and funcs "pkg-Name"
+		for _, sep := range []string{".", "-"} {
+			if rest, ok := strings.CutPrefix(d.target, "test_output"+sep); ok {
+				require.False(t, seen[diagnostic{d.id, "test_output/subpackage" + sep + rest, d.text}], "%s repeats %s", d.target, d.text)
+			}
+		}
+	}
+	for _, id := range []string{"GO012", "GO022", "GO023", "GO024", "GO026", "GO027", "GO028"} {
+		found := false
+		for d := range seen {
+			found = found || d.id == id
+		}
+		require.True(t, found, "test_output should have a %s diagnostic", id)
+	}
+}
+
+func TestHyphenatedModuleReceivers(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_hyphenated_module"), nil)
+	require.NoError(t, err)
+	lineIDs := map[string]bool{}
+	forAll(review.ReviewLines, func(rl ReviewLine) {
+		lineIDs[rl.LineID] = true
+	})
+	for method, typeID := range map[string]string{
+		"test-hyphenated-module-(c *Client) Do":         "test-hyphenated-module.Client",
+		"test-hyphenated-module-(p *Pager[T]) NextPage": "test-hyphenated-module.Pager",
+	} {
+		require.True(t, lineIDs[method], "missing "+method)
+		require.True(t, lineIDs[typeID], "missing "+typeID)
+		linked := false
+		forAll(review.ReviewLines, func(rl ReviewLine) {
+			if rl.LineID == method {
+				// the receiver type is the first type name token
+				for _, tk := range rl.Tokens {
+					if tk.Kind == TokenKindTypeName {
+						require.Equal(t, typeID, tk.NavigateToID, method)
+						linked = true
+						break
+					}
+				}
+			}
+		})
+		require.True(t, linked, method+" has no receiver type link")
+	}
+}
+
+func TestInlineFieldTypes(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_output"), nil)
+	require.NoError(t, err)
+	lines := map[string]ReviewLine{}
+	forAll(review.ReviewLines, func(rl ReviewLine) {
+		if rl.LineID != "" {
+			lines[rl.LineID] = rl
+		}
+	})
+
+	options, ok := lines["test_output/subpackage.StructInline-Options"]
+	require.True(t, ok, "missing Options field")
+	require.Equal(t, "struct", options.Tokens[len(options.Tokens)-1].Value)
+	ids := []string{}
+	for _, c := range options.Children {
+		ids = append(ids, c.LineID)
+	}
+	require.Equal(t, []string{
+		"test_output/subpackage.StructInline-Options-Retry",
+		"test_output/subpackage.StructInline-Options-Timeout",
+	}, ids)
+
+	onRetry, ok := lines["test_output/subpackage.StructInline-OnRetry"]
+	require.True(t, ok, "missing OnRetry field")
+	values := []string{}
+	// skip the field name and alignment padding
+	for _, tk := range onRetry.Tokens[2:] {
+		values = append(values, tk.Value)
+	}
+	require.Equal(t, []string{"func", "(", "attempt", "int", ",", "err", "error", ")", "bool"}, values)
+
+	// anonymous structs within other types also render as nested fields
+	for id, expected := range map[string][]string{
+		"test_output/subpackage.StructInlineShapes-ByName": {"map", "[", "string", "]", "*", "struct"},
+		"test_output/subpackage.StructInlineShapes-Items":  {"[", "]", "struct"},
+		"test_output/subpackage.StructInlineShapes-Ptr":    {"*", "struct"},
+	} {
+		field, ok := lines[id]
+		require.True(t, ok, "missing "+id)
+		values := []string{}
+		for _, tk := range field.Tokens[2:] {
+			if tk.Value != "" {
+				values = append(values, tk.Value)
+			}
+		}
+		require.Equal(t, expected, values, id)
+		require.Len(t, field.Children, 1, id)
+	}
+}
+
 func Test_getPackageNameFromModPath(t *testing.T) {
 	require.EqualValues(t, "foo", getPackageNameFromModPath("foo"))
 	require.EqualValues(t, "foo", getPackageNameFromModPath("foo/v2"))
@@ -313,9 +604,9 @@ func Test_getPackageNameFromModPath(t *testing.T) {
 
 func TestDeterministicOutput(t *testing.T) {
 	for i := 0; i < 100; i++ {
-		review1, err := createReview(filepath.Clean("testdata/test_multi_recursive_alias"))
+		review1, err := createReview(filepath.Clean("testdata/test_multi_recursive_alias"), nil)
 		require.NoError(t, err)
-		review2, err := createReview(filepath.Clean("testdata/test_multi_recursive_alias"))
+		review2, err := createReview(filepath.Clean("testdata/test_multi_recursive_alias"), nil)
 		require.NoError(t, err)
 
 		output1, err := json.MarshalIndent(review1, "", " ")
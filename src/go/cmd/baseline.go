@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// BaselineMode determines what happens to diagnostics a review's baseline already has
+type BaselineMode string
+
+const (
+	// BaselineSuppress removes diagnostics the baseline has. This is the default.
+	BaselineSuppress BaselineMode = "suppress"
+	// BaselineDowngrade lowers diagnostics the baseline has to CodeDiagnosticLevelInfo
+	BaselineDowngrade BaselineMode = "downgrade"
+)
+
+// loadBaseline reads a CodeFile, such as the output of a previous review, from the file at path
+func loadBaseline(path string) (*CodeFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cf := CodeFile{}
+	if err := json.Unmarshal(b, &cf); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return &cf, nil
+}
+
+// applyBaseline returns the given diagnostics after suppressing or downgrading, according to
+// mode, those having the TargetID and Text of a diagnostic in the baseline
+func applyBaseline(baseline *CodeFile, mode BaselineMode, diagnostics []CodeDiagnostic) []CodeDiagnostic {
+	if baseline == nil {
+		return diagnostics
+	}
+	known := map[string]bool{}
+	for _, d := range baseline.Diagnostics {
+		known[d.TargetID+"\n"+d.Text] = true
+	}
+	result := []CodeDiagnostic{}
+	for _, d := range diagnostics {
+		if known[d.TargetID+"\n"+d.Text] {
+			if mode == BaselineDowngrade {
+				d.Level = CodeDiagnosticLevelInfo
+			} else {
+				continue
+			}
+		}
+		result = append(result, d)
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errCheckFailed is the error Check returns when a review has a diagnostic at or above the
+// failing level
+var errCheckFailed = errors.New("check failed")
+
+// Check reviews the module in pkgDir and writes a summary of the review's diagnostics to w. The
+// summary lists each diagnostic at or above level with its source location, then counts the
+// diagnostics of every level. Check returns an error wrapping errCheckFailed when there are such
+// diagnostics, or any error from the review. It recovers from panics, returning them as errors,
+// so callers can rely on an exit code. Progress messages and warnings go to the options' Log,
+// or stderr when that's nil, so w gets only the summary. Options may be nil.
+func Check(pkgDir string, level CodeDiagnosticLevel, o *ReviewOptions, w io.Writer) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("failed to review %s: %v", pkgDir, p)
+		}
+	}()
+	opts := ReviewOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Log == nil {
+		opts.Log = os.Stderr
+	}
+	r, err := NewReview(pkgDir, &opts)
+	if err != nil {
+		return err
+	}
+	cf, err := r.Review()
+	if err != nil {
+		return err
+	}
+	counts := map[CodeDiagnosticLevel]int{}
+	failures := 0
+	for _, d := range cf.Diagnostics {
+		counts[d.Level]++
+		if d.Level < level {
+			continue
+		}
+		failures++
+		where := d.TargetID
+		if loc, ok := r.location(d.TargetID); ok {
+			where = loc.in(pkgDir).String()
+		}
+		fmt.Fprintf(w, "%s: %s %s: %s (%s)\n", where, d.Level, d.DiagnosticID, d.Text, d.TargetID)
+	}
+	summary := []string{}
+	for l := CodeDiagnosticLevelFatal; l >= CodeDiagnosticLevelInfo; l-- {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[l], l))
+	}
+	fmt.Fprintf(w, "%s: %s\n", cf.Name, strings.Join(summary, ", "))
+	if failures > 0 {
+		return fmt.Errorf("%w: %d diagnostics at or above %s level", errCheckFailed, failures, level)
+	}
+	return nil
+}
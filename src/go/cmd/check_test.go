@@ -0,0 +1,89 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	dir := filepath.Clean("testdata/test_directives")
+	const summary = "test_directives: 0 fatal, 0 error, 1 warning, 2 info\n"
+
+	var out bytes.Buffer
+	require.NoError(t, Check(dir, CodeDiagnosticLevelFatal, nil, &out))
+	require.Equal(t, summary, out.String())
+
+	out.Reset()
+	err := Check(dir, CodeDiagnosticLevelWarning, nil, &out)
+	require.ErrorIs(t, err, errCheckFailed)
+	require.Contains(t, err.Error(), "1 diagnostics at or above warning level")
+	require.Equal(t, "testdata/test_directives/test.go:33:2: warning GO902: Unknown apiview directive //apiview:bogus (test_directives.Constant)\n"+summary, out.String())
+
+	out.Reset()
+	err = Check(dir, CodeDiagnosticLevelInfo, nil, &out)
+	require.ErrorIs(t, err, errCheckFailed)
+	require.Contains(t, err.Error(), "3 diagnostics at or above info level")
+}
+
+func TestCheckLog(t *testing.T) {
+	// copy a fixture to a module whose config has a suppression matching no diagnostic
+	dir := filepath.Join(t.TempDir(), "test_directives")
+	require.NoError(t, os.Mkdir(dir, 0700))
+	for _, f := range []string{"go.mod", "test.go"} {
+		b, err := os.ReadFile(filepath.Join("testdata", "test_directives", f))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), b, 0600))
+	}
+	cfg := `{"suppressions": [{"target": "test_directives.Nonexistent", "justification": "stale"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte(cfg), 0600))
+	// and a declaration the parser doesn't handle, about which it warns
+	src := "package test_directives\n\nvar grid [3]string\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "grid.go"), []byte(src), 0600))
+	const summary = "test_directives: 0 fatal, 0 error, 1 warning, 2 info\n"
+
+	// progress messages and warnings go to the log, not w
+	var log, out bytes.Buffer
+	require.NoError(t, Check(dir, CodeDiagnosticLevelFatal, &ReviewOptions{Log: &log}, &out))
+	require.Equal(t, summary, out.String())
+	require.Equal(t, "Indexing "+dir+"\nunhandled declaration [3]string\n"+configFileName+`: suppression of "test_directives.Nonexistent" doesn't match any diagnostic`+"\n", log.String())
+
+	// by default they go to stderr, leaving stdout to the summary
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	err = Check(dir, CodeDiagnosticLevelFatal, nil, os.Stdout)
+	require.NoError(t, w.Close())
+	os.Stdout = stdout
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, summary, string(b))
+}
+
+func TestCheckErrors(t *testing.T) {
+	var out bytes.Buffer
+	err := Check(filepath.Clean("testdata/nonexistent"), CodeDiagnosticLevelFatal, nil, &out)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, errCheckFailed))
+	require.Error(t, CreateAPIView(filepath.Clean("testdata/nonexistent"), t.TempDir(), nil))
+
+	// Check should report a panic during the review as an error
+	const id = "TEST002"
+	RegisterRule(NewRule(id, "", func(*Module) []CodeDiagnostic { panic("oops") }))
+	defer delete(rules, id)
+	err = Check(filepath.Clean("testdata/test_directives"), CodeDiagnosticLevelFatal, nil, &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "oops")
+	require.False(t, errors.Is(err, errCheckFailed))
+}
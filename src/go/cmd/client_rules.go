@@ -0,0 +1,270 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	clientMethodContext = "Client methods must take a context.Context as their first parameter"
+	clientMethodOptions = "The last parameter should be "
+	clientMethodReturns = "The method should return "
+	clientNoCtor        = "Client has no constructor or factory method"
+	clientNoOptions     = "Client has a constructor but no options type "
+	clientOptionsEmbed  = "Client options type should embed azcore.ClientOptions: "
+	clientCtorOptions   = "Client constructor should take options as its last parameter: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO004", helpClientMethods, checkClientMethodContext))
+	RegisterRule(NewRule("GO005", helpClientMethods, checkClientMethodOptions))
+	RegisterRule(NewRule("GO006", helpClientMethods, checkClientMethodReturns))
+	RegisterRule(NewRule("GO007", helpClientConstructors, checkClientConstruction))
+}
+
+// clientOptionsRgx matches the embedded field of a client options type, which may be azcore.ClientOptions
+// or its alias policy.ClientOptions
+var clientOptionsRgx = regexp.MustCompile(`^(?:azcore|policy)\.ClientOptions$`)
+
+// checkClientConstruction flags exported clients that applications can't construct, and clients whose
+// constructors don't take an options type following the convention
+//
+//	type FooClientOptions struct {
+//		azcore.ClientOptions
+//	}
+//
+//	func NewFooClient(..., options *FooClientOptions) (*FooClient, error)
+//
+// ARM clients are exempt from the options type convention because they take *arm.ClientOptions.
+func checkClientConstruction(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		// clients any client has a factory method for e.g. "(c *Client) NewSubClient() *SubClient"
+		factories := map[string]bool{}
+		for _, fn := range p.c.Funcs {
+			if fn.Exported() && strings.HasSuffix(fn.receiverBaseType, "Client") && strings.HasPrefix(fn.Name(), "New") {
+				for _, rt := range fn.returnBaseTypes {
+					factories[rt] = true
+				}
+			}
+		}
+		for name, s := range p.c.Structs {
+			// aliases for clients of reviewed packages are checked at their definitions
+			if !s.Exported() || !strings.HasSuffix(name, "Client") || m.reviewedAlias(p, name) {
+				continue
+			}
+			ctors := []Func{}
+			for _, ctor := range p.c.findCtors(name) {
+				if strings.HasPrefix(ctor.Name(), "New"+name) {
+					ctors = append(ctors, ctor)
+				}
+			}
+			if len(ctors) == 0 {
+				if !factories[name] {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.ID(),
+						Text:     clientNoCtor,
+					})
+				}
+				continue
+			}
+			optionsName := name + "Options"
+			options, hasOptions := p.c.Structs[optionsName]
+			arm := false
+			for _, ctor := range ctors {
+				n := len(ctor.paramTypes)
+				last := ""
+				if n > 0 {
+					last = stripNavigators(ctor.paramTypes[n-1])
+				}
+				if last == "*arm.ClientOptions" {
+					arm = true
+					continue
+				}
+				if last != "*"+optionsName {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.ID(),
+						Text:     clientCtorOptions + ctor.Name(),
+					})
+				}
+			}
+			if arm {
+				continue
+			}
+			if !hasOptions {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: s.ID(),
+					Text:     clientNoOptions + optionsName,
+				})
+			} else if !slices.ContainsFunc(options.AnonymousFields, clientOptionsRgx.MatchString) {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: s.ID(),
+					Text:     clientOptionsEmbed + optionsName,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// navigatorRgx matches the navigation prefixes translateType adds to type names e.g. "<azcore.Foo>"
+var navigatorRgx = regexp.MustCompile(`<[^>]*>`)
+
+// stripNavigators removes all navigation prefixes from a type string e.g. "*runtime.Pager[<azcore.Foo>Foo]"
+// becomes "*runtime.Pager[Foo]"
+func stripNavigators(s string) string {
+	return navigatorRgx.ReplaceAllString(s, "")
+}
+
+// clientMethod describes an exported method on a client type such as "(c *Client) Get"
+type clientMethod struct {
+	fn Func
+	// kind is "Begin" for methods starting long-running operations, "Pager" for methods
+	// returning pagers, "New" for other constructors such as subclient factories, or ""
+	kind string
+	// operation is the method's name without any "Begin" prefix or "New" prefix and "Pager"
+	// suffix e.g. "List" for "NewListPager"
+	operation string
+}
+
+// clientMethods returns the exported methods on exported client types, which are types having
+// names ending in "Client". It omits methods of aliases for clients of the module's reviewed
+// packages because rules check those at their definitions.
+func clientMethods(m *Module) []clientMethod {
+	methods := []clientMethod{}
+	for _, p := range m.reviewedPackages() {
+		for _, fn := range p.c.Funcs {
+			client := fn.receiverBaseType
+			if !fn.Exported() || !strings.HasSuffix(client, "Client") || !token.IsExported(client) || m.reviewedAlias(p, client) {
+				continue
+			}
+			cm := clientMethod{fn: fn, operation: fn.Name()}
+			if op, ok := strings.CutPrefix(fn.Name(), "Begin"); ok && op != "" {
+				cm.kind, cm.operation = "Begin", op
+			} else if op, ok := strings.CutPrefix(fn.Name(), "New"); ok {
+				cm.kind = "New"
+				if op, ok = strings.CutSuffix(op, "Pager"); ok && op != "" {
+					cm.kind, cm.operation = "Pager", op
+				}
+			}
+			methods = append(methods, cm)
+		}
+	}
+	return methods
+}
+
+// isOperation returns true when the method sends requests, which is the case for every client
+// method except factories such as NewSubClient and accessors such as "Endpoint() string", which
+// take no parameters and can't fail. It doesn't consider whether the method returns an error
+// because operations lacking that return are what checkClientMethodReturns should flag.
+func (cm clientMethod) isOperation() bool {
+	switch cm.kind {
+	case "Begin", "Pager":
+		return true
+	case "New":
+		// subclient factory
+		return false
+	}
+	if len(cm.fn.paramTypes) > 0 {
+		return true
+	}
+	for _, r := range cm.fn.Returns {
+		if stripNavigators(r) == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkClientMethodContext flags client operations not taking a context.Context first. Pager
+// methods are exempt because pagers take a context when fetching each page.
+func checkClientMethodContext(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, cm := range clientMethods(m) {
+		if !cm.isOperation() || cm.kind == "Pager" {
+			continue
+		}
+		if len(cm.fn.paramTypes) == 0 || stripNavigators(cm.fn.paramTypes[0]) != "context.Context" {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: cm.fn.ID(),
+				Text:     clientMethodContext,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// checkClientMethodOptions flags client operations whose last parameter isn't "options *<Client><Method>Options"
+func checkClientMethodOptions(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, cm := range clientMethods(m) {
+		if !cm.isOperation() {
+			continue
+		}
+		method := cm.fn.Name()
+		if cm.kind == "Pager" {
+			// e.g. NewListPager takes *ClientListOptions
+			method = cm.operation
+		}
+		want := fmt.Sprintf("options *%s%sOptions", cm.fn.receiverBaseType, method)
+		n := len(cm.fn.paramNames)
+		if n == 0 || cm.fn.paramNames[n-1]+" "+stripNavigators(cm.fn.paramTypes[n-1]) != want {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: cm.fn.ID(),
+				Text:     clientMethodOptions + want,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// checkClientMethodReturns flags client operations not returning "(<Client><Method>Response, error)".
+// Methods beginning long-running operations must return "(*runtime.Poller[<Client><Operation>Response], error)"
+// and pager methods must return "*runtime.Pager[<Client><Operation>Response]".
+func checkClientMethodReturns(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, cm := range clientMethods(m) {
+		if !cm.isOperation() {
+			continue
+		}
+		response := cm.fn.receiverBaseType + cm.operation + "Response"
+		want := ""
+		switch cm.kind {
+		case "Begin":
+			want = fmt.Sprintf("(*runtime.Poller[%s], error)", response)
+		case "Pager":
+			want = fmt.Sprintf("*runtime.Pager[%s]", response)
+		default:
+			want = fmt.Sprintf("(%s, error)", response)
+		}
+		returns := make([]string, len(cm.fn.Returns))
+		for i, r := range cm.fn.Returns {
+			returns[i] = stripNavigators(r)
+		}
+		actual := strings.Join(returns, ", ")
+		if len(returns) > 1 {
+			actual = "(" + actual + ")"
+		}
+		if actual != want {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: cm.fn.ID(),
+				Text:     clientMethodReturns + want,
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,178 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configFileName is the name of the optional file in a module's root directory that configures
+// the module's diagnostics
+const configFileName = "apiviewgo.json"
+
+// Config configures diagnostics for a module. A module's config is the content of the
+// apiviewgo.json file in its root directory, for example:
+//
+//	{
+//	  "allowedDependencies": ["github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"],
+//	  "rules": {
+//	    "GO001": { "enabled": false },
+//	    "GO002": { "level": "warning" }
+//	  },
+//	  "suppressions": [
+//	    { "target": "azblob.Client", "rule": "GO002", "justification": "embedding is intentional" }
+//	  ]
+//	}
+type Config struct {
+	// AllowedDependencies lists module paths the module's exported API may refer to in addition
+	// to the standard library and defaultAllowedDependencies
+	AllowedDependencies []string `json:"allowedDependencies,omitempty"`
+	// Rules maps rule IDs to their configuration
+	Rules map[string]RuleConfig `json:"rules,omitempty"`
+	// Suppressions silence individual diagnostics
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+	// Words lists words the spelling check accepts
+	Words []string `json:"words,omitempty"`
+}
+
+// RuleConfig configures a Rule
+type RuleConfig struct {
+	// Enabled turns the rule on or off. The rule is enabled when this is nil.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Level overrides the level of the rule's diagnostics. It must be "info", "warning", "error" or "fatal".
+	Level string `json:"level,omitempty"`
+}
+
+// Suppression silences the diagnostics of a ReviewLine
+type Suppression struct {
+	// Justification explains why the diagnostic doesn't apply. Required.
+	Justification string `json:"justification"`
+	// Rule limits the suppression to diagnostics having this DiagnosticID. When it's empty,
+	// the suppression applies to all diagnostics of the target line.
+	Rule string `json:"rule,omitempty"`
+	// Target is the LineID of the ReviewLine whose diagnostics to suppress. Required.
+	Target string `json:"target"`
+}
+
+// loadConfig reads the config file from the module root dir. It returns an empty Config
+// when the module has no config file.
+func loadConfig(dir string) (Config, error) {
+	cfg := Config{}
+	p := filepath.Join(dir, configFileName)
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err == nil {
+		err = json.Unmarshal(b, &cfg)
+	}
+	if err == nil {
+		err = cfg.validate()
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("invalid %s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// validate returns an error describing the first problem it finds in the config, if any
+func (c Config) validate() error {
+	for id, rc := range c.Rules {
+		if _, ok := rules[id]; !ok {
+			return fmt.Errorf("unknown rule %q", id)
+		}
+		if rc.Level != "" {
+			if _, err := parseLevel(rc.Level); err != nil {
+				return fmt.Errorf("rule %s: %w", id, err)
+			}
+		}
+	}
+	for _, d := range c.AllowedDependencies {
+		if d == "" {
+			return errors.New("allowed dependency is empty")
+		}
+	}
+	for _, s := range c.Suppressions {
+		if s.Target == "" {
+			return errors.New("suppression has no target")
+		}
+		if strings.TrimSpace(s.Justification) == "" {
+			return fmt.Errorf("suppression of %q has no justification", s.Target)
+		}
+	}
+	return nil
+}
+
+// enabled returns whether the config enables the rule having the given ID
+func (c Config) enabled(id string) bool {
+	rc, ok := c.Rules[id]
+	return !ok || rc.Enabled == nil || *rc.Enabled
+}
+
+// apply returns the diagnostics remaining after applying the config's level overrides and
+// suppressions to the given diagnostics. It warns on log of suppressions matching no diagnostic.
+func (c Config) apply(diagnostics []CodeDiagnostic, log io.Writer) []CodeDiagnostic {
+	used := make([]bool, len(c.Suppressions))
+	result := []CodeDiagnostic{}
+	for _, d := range diagnostics {
+		suppressed := false
+		for i, s := range c.Suppressions {
+			if s.Target == d.TargetID && (s.Rule == "" || s.Rule == d.DiagnosticID) {
+				suppressed = true
+				used[i] = true
+			}
+		}
+		if suppressed {
+			continue
+		}
+		if rc, ok := c.Rules[d.DiagnosticID]; ok && rc.Level != "" {
+			// validate() ensures the level is valid
+			d.Level, _ = parseLevel(rc.Level)
+		}
+		result = append(result, d)
+	}
+	for i, s := range c.Suppressions {
+		if !used[i] {
+			fmt.Fprintf(log, "%s: suppression of %q doesn't match any diagnostic\n", configFileName, s.Target)
+		}
+	}
+	return result
+}
+
+// String returns the level's name as parseLevel accepts it e.g. "warning"
+func (l CodeDiagnosticLevel) String() string {
+	switch l {
+	case CodeDiagnosticLevelInfo:
+		return "info"
+	case CodeDiagnosticLevelWarning:
+		return "warning"
+	case CodeDiagnosticLevelError:
+		return "error"
+	case CodeDiagnosticLevelFatal:
+		return "fatal"
+	}
+	return fmt.Sprintf("CodeDiagnosticLevel(%d)", int(l))
+}
+
+// parseLevel returns the CodeDiagnosticLevel named by s e.g. "warning"
+func parseLevel(s string) (CodeDiagnosticLevel, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return CodeDiagnosticLevelInfo, nil
+	case "warning":
+		return CodeDiagnosticLevelWarning, nil
+	case "error":
+		return CodeDiagnosticLevelError, nil
+	case "fatal":
+		return CodeDiagnosticLevelFatal, nil
+	}
+	return 0, fmt.Errorf("invalid level %q", s)
+}
@@ -38,6 +38,13 @@ type content struct {
 	Structs map[string]Struct `json:"structs,omitempty"`
 
 	Vars map[string]Declaration
+
+	// errorTypes names the types implementing error. It's set before generating review lines
+	// because that removes the methods findErrorTypes looks for.
+	errorTypes map[string]bool
+
+	// opts configures the review lines generated from the content
+	opts ReviewOptions
 }
 
 // newContent returns an initialized Content object.
@@ -63,7 +70,7 @@ func (c *content) addGenDecl(pkg Pkg, tok token.Token, vs *ast.ValueSpec, import
 	if len(vs.Values) > 0 {
 		v := getExprValue(pkg, vs.Values[0])
 		if v == "" {
-			fmt.Println("failed to determine value for " + pkg.getText(vs.Pos(), vs.End()))
+			pkg.warn("failed to determine value for %s", pkg.getText(vs.Pos(), vs.End()))
 		}
 	}
 	decl := NewDeclaration(pkg, vs, imports)
@@ -74,7 +81,7 @@ func (c *content) addGenDecl(pkg Pkg, tok token.Token, vs *ast.ValueSpec, import
 	case token.VAR:
 		c.Vars[vs.Names[0].Name] = decl
 	default:
-		fmt.Printf("unexpected declaration kind %v\n", vs.Names[0].Obj.Kind)
+		pkg.warn("unexpected declaration kind %v", vs.Names[0].Obj.Kind)
 	}
 	return decl
 }
@@ -111,7 +118,7 @@ func getExprValue(pkg Pkg, expr ast.Expr) string {
 		// const FooConst = -1
 		return pkg.getText(x.Pos(), x.End())
 	default:
-		fmt.Printf("unhandled expression value type %T\n", expr)
+		pkg.warn("unhandled expression value type %T", expr)
 		txt := pkg.getText(expr.Pos(), expr.End())
 		return txt
 	}
@@ -187,7 +194,7 @@ func (c *content) parseDeclarations(decls map[string]Declaration, kind string) [
 			}
 		}
 	}
-	sort.Strings(keys)
+	sortNames(keys, c.opts.Layout, func(name string) token.Position { return decls[name].pos })
 	sort.Strings(types)
 	// finalKeys will order const keys by their type
 	finalKeys := []string{}
@@ -231,16 +238,24 @@ func (c *content) parseDeclarations(decls map[string]Declaration, kind string) [
 // level function by parseFunc. Note this means searchForPossibleValuesMethod must be called before parseFunc.
 // If the type doesn't have a corresponding PossibleValues function, searchForPossibleValuesMethod returns nil.
 func (c *content) searchForPossibleValuesMethod(t string) *ReviewLine {
-	for i, f := range c.Funcs {
-		if f.Name() == fmt.Sprintf("Possible%sValues", removeNavigatorString(t)) {
-			fl := f.MakeReviewLine()
-			delete(c.Funcs, i)
-			return &fl
-		}
+	if k, ok := c.findPossibleValuesFunc(removeNavigatorString(t)); ok {
+		fl := c.Funcs[k].MakeReviewLine()
+		delete(c.Funcs, k)
+		return &fl
 	}
 	return nil
 }
 
+// findPossibleValuesFunc returns the key in c.Funcs of the named type's PossibleValues function, if it exists
+func (c *content) findPossibleValuesFunc(t string) (string, bool) {
+	for k, f := range c.Funcs {
+		if f.Name() == fmt.Sprintf("Possible%sValues", t) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
 // addFunc adds the specified function declaration to the exports list
 // The imports map stores the key value pair for package imports which will be used to identify types.
 func (c *content) addFunc(pkg Pkg, f *ast.FuncDecl, imports map[string]string) Func {
@@ -312,7 +327,9 @@ func (c *content) parseStructs() []ReviewLine {
 	}
 	sort.Strings(keys)
 	for _, typeName := range keys {
-		sl := c.Structs[typeName].MakeReviewLine()
+		st := c.Structs[typeName]
+		st.opts = c.opts
+		sl := st.MakeReviewLine()
 		ctors := c.searchForCtors(typeName)
 		methods := c.findMethods(typeName)
 		if len(sl.Children) > 0 && (len(ctors) > 0 || len(methods) > 0) {
@@ -324,7 +341,7 @@ func (c *content) parseStructs() []ReviewLine {
 			for k := range ctors {
 				keys = append(keys, k)
 			}
-			sort.Strings(keys)
+			sortNames(keys, c.opts.Layout, func(k string) token.Position { return ctors[k].pos })
 			for _, k := range keys {
 				cl := ctors[k].MakeReviewLine()
 				cl.RelatedToLine = sl.LineID
@@ -337,7 +354,7 @@ func (c *content) parseStructs() []ReviewLine {
 			for name := range methods {
 				names = append(names, name)
 			}
-			sort.Strings(names)
+			sortNames(names, c.opts.Layout, func(name string) token.Position { return methods[name].pos })
 			for _, name := range names {
 				ml := methods[name].MakeReviewLine()
 				ml.RelatedToLine = sl.LineID
@@ -358,27 +375,30 @@ func (c *content) parseStructs() []ReviewLine {
 }
 
 // searchForCtors searches through exported Funcs for constructors of a type,
-// deleting any that are found so they won't be parsed by parseFunc. A Func
-// is a constructor of type T when:
+// deleting any that are found so they won't be parsed by parseFunc. See
+// findCtors for the definition of a constructor.
+func (c *content) searchForCtors(s string) map[string]Func {
+	ctors := c.findCtors(s)
+	for key := range ctors {
+		delete(c.Funcs, key)
+	}
+	return ctors
+}
+
+// findCtors returns the constructors of a type without modifying the content.
+// A Func is a constructor of type T when:
 // 1. it has no receiver
 // 2. its name begins with "New"
 // 3. it returns T or *T
-func (c *content) searchForCtors(s string) map[string]Func {
+func (c *content) findCtors(s string) map[string]Func {
 	ctors := map[string]Func{}
 	for key, f := range c.Funcs {
 		if f.ReceiverType != "" || !strings.HasPrefix(f.Name(), "New") {
 			continue
 		}
-		for _, rt := range f.Returns {
-			if before, _, found := strings.Cut(rt, "["); found {
-				// ignore type parameters when matching
-				rt = before
-			}
-			rt = removeNavigatorString(rt)
-			if rt == s || rt == "*"+s {
-				ctors[key] = f
-				delete(c.Funcs, key)
-			}
+		// returnBaseTypes omits pointers and type arguments, so "*Pager[T]" matches "Pager"
+		if slices.Contains(f.returnBaseTypes, s) {
+			ctors[key] = f
 		}
 	}
 	return ctors
@@ -405,19 +425,25 @@ func (c *content) findMethods(s string) map[string]Func {
 		if unicode.IsLower(rune(name[0])) {
 			continue
 		}
-		_, n := getReceiver(key)
-		if before, _, found := strings.Cut(n, "["); found {
-			// ignore type parameters when matching receivers to types
-			n = before
-		}
-		n = removeNavigatorString(n)
-		if s == n || "*"+s == n {
+		// receiverBaseType omits pointers and type parameters, so "(p *Pager[T])" matches "Pager"
+		if fn.receiverBaseType == s {
 			methods[key] = fn
 		}
 	}
 	return methods
 }
 
+// findErrorTypes returns the Error methods of the types implementing error, keyed by type name
+func (c *content) findErrorTypes() map[string]Func {
+	errs := map[string]Func{}
+	for _, fn := range c.Funcs {
+		if fn.receiverBaseType != "" && fn.Name() == "Error" && len(fn.paramTypes) == 0 && len(fn.Returns) == 1 && fn.Returns[0] == "string" {
+			errs[fn.receiverBaseType] = fn
+		}
+	}
+	return errs
+}
+
 // searchForMethods takes the name of the receiver and looks for Funcs that are methods on that receiver.
 // It deletes the methods from the content so they won't be parsed by parseFunc.
 func (c *content) searchForMethods(s string) []ReviewLine {
@@ -427,7 +453,7 @@ func (c *content) searchForMethods(s string) []ReviewLine {
 	for key := range methods {
 		methodNames = append(methodNames, key)
 	}
-	sort.Strings(methodNames)
+	sortNames(methodNames, c.opts.Layout, func(key string) token.Position { return methods[key].pos })
 	for _, name := range methodNames {
 		fn := methods[name]
 		lines = append(lines, fn.MakeReviewLine())
@@ -478,13 +504,33 @@ func (c *content) parseFunc() []ReviewLine {
 			keys = append(keys, key)
 		}
 	}
-	sort.Strings(keys)
+	sortNames(keys, c.opts.Layout, func(k string) token.Position { return c.Funcs[k].pos })
 	for _, k := range keys {
 		lns = append(lns, c.Funcs[k].MakeReviewLine())
 	}
 	return lns
 }
 
+// sortNames sorts names alphabetically or, given LayoutSource, by the source position pos returns
+// for each name. Positions in different files are ordered by file name.
+func sortNames(names []string, layout Layout, pos func(string) token.Position) {
+	if layout != LayoutSource {
+		sort.Strings(names)
+		return
+	}
+	slices.SortStableFunc(names, func(a, b string) int {
+		pa, pb := pos(a), pos(b)
+		if c := strings.Compare(pa.Filename, pb.Filename); c != 0 {
+			return c
+		}
+		if pa.Offset != pb.Offset {
+			return pa.Offset - pb.Offset
+		}
+		// fall back to names so the order is deterministic
+		return strings.Compare(a, b)
+	})
+}
+
 // generateNavChildItems will loop through all the consts, interfaces, structs and global functions
 // to create the navigation items that will be displayed in the API view.
 // For consts, a navigation item will be by const type.
@@ -519,36 +565,49 @@ func (c *content) generateNavChildItems() []NavigationItem {
 	}
 	for _, i := range c.Interfaces {
 		if i.Exported() {
+			tags := map[string]string{
+				"TypeKind": "interface",
+			}
+			if i.Constraint {
+				// the interface has type set elements, so it can only constrain type parameters
+				tags["Constraint"] = "true"
+			}
 			items = append(items, NavigationItem{
 				Text:         i.Name(),
 				NavigationID: i.ID(),
 				ChildItems:   []NavigationItem{},
-				Tags: &map[string]string{
-					"TypeKind": "interface",
-				},
+				Tags:         &tags,
 			})
 		}
 	}
 	for _, n := range c.SimpleTypes {
 		if n.Exported() {
+			kind := "struct"
+			if c.errorTypes[n.Name()] {
+				kind = "error"
+			}
 			items = append(items, NavigationItem{
 				Text:         n.Name(),
 				NavigationID: n.ID(),
 				ChildItems:   []NavigationItem{},
 				Tags: &map[string]string{
-					"TypeKind": "struct",
+					"TypeKind": kind,
 				},
 			})
 		}
 	}
 	for _, s := range c.Structs {
 		if s.Exported() {
+			kind := "class"
+			if c.errorTypes[s.Name()] {
+				kind = "error"
+			}
 			items = append(items, NavigationItem{
 				Text:         s.Name(),
 				NavigationID: s.ID(),
 				ChildItems:   []NavigationItem{},
 				Tags: &map[string]string{
-					"TypeKind": "class",
+					"TypeKind": kind,
 				},
 			})
 		}
@@ -0,0 +1,217 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"sort"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	disallowedDependency = "Exported API refers to a module that isn't an allowed dependency: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO019", helpDependencies, checkDependencies))
+}
+
+// defaultAllowedDependencies are the modules any module's exported API may refer to, in addition
+// to the standard library and internal modules visible to the module. Config.AllowedDependencies
+// extends this list.
+var defaultAllowedDependencies = []string{
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore",
+}
+
+// dependency is a module, or standard library package, which a package's exported API refers to
+type dependency struct {
+	// path is the module path or, for the standard library, the package's import path
+	path string
+	// refs are the LineIDs of the declarations referring to the dependency
+	refs []string
+	// stdlib indicates the dependency is a standard library package
+	stdlib bool
+	// version is the module version the go.mod file requires, if any
+	version string
+}
+
+// dependencies returns the modules and standard library packages the exported API of the given
+// package refers to, sorted by path
+func (m *Module) dependencies(p *Pkg) []dependency {
+	deps := map[string]*dependency{}
+	add := func(importPath, id string) {
+		if importPath == "" || m.contains(importPath) {
+			return
+		}
+		d := m.dependency(importPath)
+		if existing, ok := deps[d.path]; ok {
+			d = existing
+		} else {
+			deps[d.path] = d
+		}
+		if len(d.refs) == 0 || d.refs[len(d.refs)-1] != id {
+			d.refs = append(d.refs, id)
+		}
+	}
+	for _, ref := range m.typeRefs(p) {
+		for _, sel := range selectors(ref.t) {
+			add(m.importPath(p, sel.qualifier), ref.id)
+		}
+	}
+	for _, ta := range p.TypeAliases {
+		if i := strings.LastIndex(ta.QualifiedName, "."); i > 0 && ta.Package == p {
+			add(ta.QualifiedName[:i], p.Name()+"."+ta.Name)
+		}
+	}
+	result := make([]dependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].path < result[j].path })
+	return result
+}
+
+// contains returns whether the package at importPath is in the module
+func (m *Module) contains(importPath string) bool {
+	mod := m.ModFile.Module.Mod.Path
+	return importPath == mod || strings.HasPrefix(importPath, mod+"/")
+}
+
+// dependency returns a dependency for the module providing the package at importPath
+func (m *Module) dependency(importPath string) *dependency {
+	d := &dependency{path: importPath}
+	matched := ""
+	for _, req := range m.ModFile.Require {
+		// modules can be nested, so prefer the longest match
+		if (importPath == req.Mod.Path || strings.HasPrefix(importPath, req.Mod.Path+"/")) && len(req.Mod.Path) > len(matched) {
+			matched = req.Mod.Path
+			d.path, d.version = req.Mod.Path, req.Mod.Version
+		}
+	}
+	d.stdlib = matched == "" && isStdlib(importPath)
+	return d
+}
+
+// isStdlib returns whether importPath is a standard library package's, which unlike other
+// import paths don't begin with a domain name
+func isStdlib(importPath string) bool {
+	return !strings.Contains(strings.Split(importPath, "/")[0], ".")
+}
+
+// importPath returns the import path for the given package qualifier as it appears in p's API.
+// Types hoisted from other packages carry those packages' qualifiers, so when p doesn't import
+// the qualifier, importPath looks for it in the module's other packages.
+func (m *Module) importPath(p *Pkg, qualifier string) string {
+	if ip, ok := p.imports[qualifier]; ok {
+		return ip
+	}
+	names := make([]string, 0, len(m.Packages))
+	for name := range m.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ip, ok := m.Packages[name].imports[qualifier]; ok {
+			return ip
+		}
+	}
+	return ""
+}
+
+// allowedDependency returns whether the module's exported API may refer to the dependency. Like
+// Go's rule for importing internal packages, the module may refer to an internal module rooted
+// at the parent of the "internal" element e.g. "github.com/Azure/azure-sdk-for-go/sdk/internal"
+// for any module under "github.com/Azure/azure-sdk-for-go/sdk".
+func (m *Module) allowedDependency(d dependency) bool {
+	if d.stdlib {
+		return true
+	}
+	if parent, _, found := strings.Cut(d.path+"/", "/internal/"); found && strings.HasPrefix(m.ModFile.Module.Mod.Path, parent+"/") {
+		return true
+	}
+	for _, a := range append(append([]string{}, defaultAllowedDependencies...), m.config.AllowedDependencies...) {
+		if d.path == a || strings.HasPrefix(d.path, a+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// selector is a qualified identifier such as "azcore.ClientOptions"
+type selector struct {
+	qualifier, name string
+}
+
+// selectors returns the qualified identifiers in the expression x e.g. "azcore.ClientOptions" in
+// "*azcore.ClientOptions". It returns nil when x isn't a valid expression.
+func selectors(x string) []selector {
+	expr, err := parser.ParseExpr(strings.TrimPrefix(stripNavigators(x), "..."))
+	if err != nil {
+		return nil
+	}
+	sels := []selector{}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if se, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := se.X.(*ast.Ident); ok {
+				sels = append(sels, selector{qualifier: ident.Name, name: se.Sel.Name})
+			}
+			return false
+		}
+		return true
+	})
+	return sels
+}
+
+// checkDependencies flags exported API referring to modules the config doesn't allow
+func checkDependencies(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, d := range m.dependencies(p) {
+			if m.allowedDependency(d) {
+				continue
+			}
+			for _, id := range d.refs {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelError,
+					TargetID: id,
+					Text:     disallowedDependency + d.path,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// makeDependencyLines returns a ReviewLine listing the modules a package's exported API refers to
+// with the number of the package's declarations referring to each, or nil when there are none.
+// The standard library isn't listed.
+func makeDependencyLines(pkgName string, deps []dependency) *ReviewLine {
+	line := ReviewLine{
+		LineID: pkgName + "-dependencies",
+		Tokens: []ReviewToken{{Kind: TokenKindComment, Value: "// external dependencies"}},
+	}
+	for _, d := range deps {
+		if d.stdlib {
+			continue
+		}
+		tks := []ReviewToken{{HasSuffixSpace: true, Kind: TokenKindText, Value: d.path}}
+		if d.version != "" {
+			tks = append(tks, ReviewToken{HasSuffixSpace: true, Kind: TokenKindText, Value: d.version})
+		}
+		refs := fmt.Sprintf("(%d references)", len(d.refs))
+		if len(d.refs) == 1 {
+			refs = "(1 reference)"
+		}
+		// the count changes with unrelated API changes, so it shouldn't appear in diffs
+		tks = append(tks, ReviewToken{Kind: TokenKindComment, SkipDiff: true, Value: refs})
+		line.Children = append(line.Children, ReviewLine{LineID: line.LineID + "-" + d.path, Tokens: tks})
+	}
+	if len(line.Children) == 0 {
+		return nil
+	}
+	return &line
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// directivePrefix begins comments that direct the content of a review, for example
+//
+//	//apiview:hide
+//	//apiview:suppress GO002 embedding is intentional
+//	//apiview:note this type is deprecated in favor of Foo
+const directivePrefix = "//apiview:"
+
+// directive kinds
+const (
+	// directiveHide hides the declaration's lines in the review
+	directiveHide = "hide"
+	// directiveNote attaches the directive's text as an Info diagnostic
+	directiveNote = "note"
+	// directiveSuppress suppresses the declaration's diagnostics having the given DiagnosticID
+	directiveSuppress = "suppress"
+)
+
+// directive is an apiview directive found in a declaration's comments
+type directive struct {
+	// arg is the text following the directive's kind, if any
+	arg string
+	// kind is directiveHide, directiveNote or directiveSuppress
+	kind string
+	// target is the LineID of the declaration
+	target string
+}
+
+// addComments records the text of the given comments as documentation of the declaration whose
+// LineID is target, and adds any directives in the comments to the package
+func (p *Pkg) addComments(target string, groups ...*ast.CommentGroup) {
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		// Text omits directives
+		if txt := g.Text(); txt != "" {
+			p.docs[target] += txt
+		}
+		for _, c := range g.List {
+			txt, found := strings.CutPrefix(c.Text, directivePrefix)
+			if !found {
+				continue
+			}
+			kind, arg, _ := strings.Cut(strings.TrimSpace(txt), " ")
+			switch kind {
+			case directiveHide, directiveNote, directiveSuppress:
+				p.directives = append(p.directives, directive{arg: strings.TrimSpace(arg), kind: kind, target: target})
+			default:
+				p.diagnostics = append(p.diagnostics, CodeDiagnostic{
+					DiagnosticID: unknownDirectiveDiagnosticID,
+					HelpLinkURI:  helpReadme,
+					Level:        CodeDiagnosticLevelWarning,
+					TargetID:     target,
+					Text:         unknownDirective + c.Text,
+				})
+			}
+		}
+	}
+}
+
+// indexGenDecl records the positions and adds the comments of a const, var or type declaration,
+// including those of struct fields and interface methods
+func (p *Pkg) indexGenDecl(x *ast.GenDecl) {
+	for _, spec := range x.Specs {
+		var doc, comment *ast.CommentGroup
+		id := ""
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			doc, comment = s.Doc, s.Comment
+			id = p.Name() + "." + s.Name.Name
+			var fields *ast.FieldList
+			switch t := s.Type.(type) {
+			case *ast.InterfaceType:
+				fields = t.Methods
+			case *ast.StructType:
+				fields = t.Fields
+			}
+			if fields != nil {
+				for _, f := range fields.List {
+					for _, n := range f.Names {
+						p.addComments(id+"-"+n.Name, f.Doc, f.Comment)
+						p.positions[id+"-"+n.Name] = p.fs.Position(n.Pos())
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			doc, comment = s.Doc, s.Comment
+			id = p.Name() + "." + s.Names[0].Name
+		default:
+			continue
+		}
+		p.positions[id] = p.fs.Position(spec.Pos())
+		if x.Lparen == token.NoPos {
+			// the declaration isn't grouped, so its doc comment belongs to its only spec
+			doc = x.Doc
+		}
+		p.addComments(id, doc, comment)
+	}
+}
+
+// applyDirectives hides the lines and suppresses the diagnostics the given directives specify,
+// returning the remaining diagnostics plus a diagnostic for each note. It drops diagnostics and
+// notes targeting hidden lines, including the descendants of lines hidden by directives.
+func applyDirectives(directives []directive, lines []ReviewLine, diagnostics []CodeDiagnostic) []CodeDiagnostic {
+	hidden := map[string]bool{}
+	suppressed := map[string]bool{}
+	notes := []CodeDiagnostic{}
+	for _, d := range directives {
+		switch d.kind {
+		case directiveHide:
+			hidden[d.target] = true
+		case directiveNote:
+			notes = append(notes, CodeDiagnostic{
+				DiagnosticID: noteDiagnosticID,
+				Level:        CodeDiagnosticLevelInfo,
+				TargetID:     d.target,
+				Text:         d.arg,
+			})
+		case directiveSuppress:
+			// the first word of the argument is the rule ID; any others are a justification
+			rule, _, _ := strings.Cut(d.arg, " ")
+			suppressed[d.target+" "+rule] = true
+		}
+	}
+	hideLines(lines, hidden, false)
+	forAll(lines, func(ln ReviewLine) {
+		if ln.IsHidden && ln.LineID != "" {
+			hidden[ln.LineID] = true
+		}
+	})
+	result := []CodeDiagnostic{}
+	for _, d := range append(diagnostics, notes...) {
+		if !hidden[d.TargetID] && !suppressed[d.TargetID+" "+d.DiagnosticID] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// hideLines sets IsHidden on lines having an ID in ids and on their descendants. It hides all
+// lines when hide is true.
+func hideLines(lines []ReviewLine, ids map[string]bool, hide bool) {
+	for i := range lines {
+		h := hide || (lines[i].LineID != "" && ids[lines[i].LineID])
+		if h {
+			lines[i].IsHidden = true
+		}
+		hideLines(lines[i].Children, ids, h)
+	}
+}
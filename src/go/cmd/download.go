@@ -23,16 +23,16 @@ var errCachedModuleNotFound = errors.New("cached module not found")
 
 // GetExternalModule returns a Module representing mod. When GOMODCACHE is set,
 // it looks for mod's source in the mod cache. Otherwise, it downloads mod from
-// the module proxy.
-func GetExternalModule(mod module.Version) (*Module, error) {
-	m, err := cachedModule(mod)
+// the module proxy. Warnings about the module's source go to log.
+func GetExternalModule(mod module.Version, log io.Writer) (*Module, error) {
+	m, err := cachedModule(mod, log)
 	if err != nil && !errors.Is(err, errCachedModuleNotFound) {
 		return nil, fmt.Errorf("failed to parse cached module %s: %w", mod.Path, err)
 	}
 	if m == nil {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 		defer cancel()
-		m, err = downloadModule(ctx, mod)
+		m, err = downloadModule(ctx, mod, log)
 	}
 	return m, err
 }
@@ -52,7 +52,7 @@ func GetExternalModule(mod module.Version) (*Module, error) {
 // obvious tidier schemes are impossible. Although downloadModule could in principle unzip
 // modules to the local Go module cache, it doesn't do so to avoid affecting other Go programs
 // or reimplementing whatever `go mod download` behavior is necessary to ensure correctness.
-func downloadModule(ctx context.Context, mod module.Version) (*Module, error) {
+func downloadModule(ctx context.Context, mod module.Version, log io.Writer) (*Module, error) {
 	d, err := downloadDir()
 	if err != nil {
 		return nil, err
@@ -111,17 +111,17 @@ func downloadModule(ctx context.Context, mod module.Version) (*Module, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to unzip %s: %w", zp, err)
 	}
-	return NewModule(p)
+	return NewModule(p, log)
 }
 
 // cachedModule returns a Module for mod if it's in either the local Go mod
 // cache or apiviewgo cache. It returns errCachedModuleNotFound when the
 // module isn't in either cache.
-func cachedModule(mod module.Version) (*Module, error) {
+func cachedModule(mod module.Version, log io.Writer) (*Module, error) {
 	if modCache := os.Getenv("GOMODCACHE"); modCache != "" {
 		d := filepath.Join(modCache, mustEscape(mod.Path)) + "@" + mod.Version
 		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
-			return NewModule(d)
+			return NewModule(d, log)
 		}
 	}
 	return nil, errCachedModuleNotFound
@@ -0,0 +1,124 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	enumForeignConst   = "Constants should be declared in the package defining their type "
+	enumNoConsts       = "There are no constants of the type this function returns values of"
+	enumNoPossibleVals = "The type has constants but no function returning all of them "
+)
+
+func init() {
+	RegisterRule(NewRule("GO015", helpEnums, checkPossibleValuesFuncs))
+	RegisterRule(NewRule("GO016", helpEnums, checkPossibleValuesTypes))
+	RegisterRule(NewRule("GO017", helpEnums, checkForeignConsts))
+}
+
+// enumValues returns the number of exported consts and vars declared in the package having the named type
+func enumValues(c content, t string) (consts, vars int) {
+	for _, d := range c.Consts {
+		if d.Exported() && d.typePkg == "" && stripNavigators(d.Type) == t {
+			consts++
+		}
+	}
+	for _, d := range c.Vars {
+		if d.Exported() && strings.TrimPrefix(stripNavigators(d.Type), "*") == t {
+			vars++
+		}
+	}
+	return
+}
+
+// checkPossibleValuesFuncs flags types having constants but no PossibleValues function
+func checkPossibleValuesFuncs(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for name, t := range p.c.SimpleTypes {
+			if !t.Exported() || m.reviewedAlias(p, name) {
+				continue
+			}
+			if consts, _ := enumValues(p.c, name); consts == 0 {
+				continue
+			}
+			if _, ok := p.c.findPossibleValuesFunc(name); !ok {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: t.ID(),
+					Text:     enumNoPossibleVals + fmt.Sprintf("Possible%sValues", name),
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkPossibleValuesTypes flags PossibleValues functions for types having no constants (or vars, for
+// types which can't be constant)
+func checkPossibleValuesTypes(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, fn := range p.c.Funcs {
+			if fn.ReceiverType != "" || !fn.Exported() {
+				continue
+			}
+			name, ok := strings.CutPrefix(fn.Name(), "Possible")
+			if !ok {
+				continue
+			}
+			name, ok = strings.CutSuffix(name, "Values")
+			if !ok || name == "" {
+				continue
+			}
+			if consts, vars := enumValues(p.c, name); consts+vars == 0 {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: fn.ID(),
+					Text:     enumNoConsts,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// isEnum returns whether the named type of the module's package having the given import path is
+// an enum, meaning that package declares constants of the type or a PossibleValues function for it
+func (m *Module) isEnum(importPath, name string) bool {
+	p, ok := m.Packages[importPath]
+	if !ok {
+		// the type isn't the module's e.g. time.Duration
+		return false
+	}
+	if _, ok := p.c.SimpleTypes[name]; !ok {
+		return false
+	}
+	_, hasFunc := p.c.findPossibleValuesFunc(name)
+	consts, _ := enumValues(p.c, name)
+	return hasFunc || consts > 0
+}
+
+// checkForeignConsts flags exported constants of enum types defined in another of the module's
+// packages. Constants of other types, such as "const DefaultTimeout time.Duration = time.Minute",
+// aren't enum values.
+func checkForeignConsts(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, d := range p.c.Consts {
+			if d.Exported() && d.typePkg != "" && m.isEnum(d.typePkg, stripNavigators(d.Type)) {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: d.ID(),
+					Text:     enumForeignConst + d.typePkg,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
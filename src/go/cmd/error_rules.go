@@ -0,0 +1,149 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	errorMissingUnwrap  = "Error types wrapping another error should have an Unwrap method"
+	errorName           = "Error type names should end with \"Error\""
+	errorPointer        = "Error should have a pointer receiver so that errors.As works with only one form of the type"
+	errorSentinelPrefix = "Sentinel error names should begin with \"Err\""
+)
+
+func init() {
+	RegisterRule(NewRule("GO023", helpErrors, checkErrorNames))
+	RegisterRule(NewRule("GO024", helpErrors, checkErrorReceivers))
+	RegisterRule(NewRule("GO025", helpErrors, checkSentinelErrors))
+	RegisterRule(NewRule("GO026", helpErrorWrapping, checkErrorUnwrap))
+}
+
+// exportedErrorTypes returns the Error methods of a package's exported types implementing error,
+// keyed by type name. It omits aliases for types of the module's reviewed packages.
+func (m *Module) exportedErrorTypes(p *Pkg) map[string]Func {
+	c := p.c
+	errs := c.findErrorTypes()
+	for name := range errs {
+		_, isStruct := c.Structs[name]
+		_, isSimple := c.SimpleTypes[name]
+		if (!isStruct && !isSimple) || !token.IsExported(name) || m.reviewedAlias(p, name) {
+			delete(errs, name)
+		}
+	}
+	return errs
+}
+
+// checkErrorNames flags exported error types whose names don't end with "Error"
+func checkErrorNames(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for name := range m.exportedErrorTypes(p) {
+			if !strings.HasSuffix(name, "Error") {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: p.Name() + "." + name,
+					Text:     errorName,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkErrorReceivers flags Error methods of struct types having value receivers
+func checkErrorReceivers(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for name, fn := range m.exportedErrorTypes(p) {
+			// other types such as "type Errno uintptr" conventionally have value receivers
+			if _, ok := p.c.Structs[name]; ok && !fn.receiverPointer {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: fn.ID(),
+					Text:     errorPointer,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// isSentinelError returns whether the var holds an error value, which it does when its type is
+// error or its value is created by errors.New or fmt.Errorf
+func isSentinelError(d Declaration) bool {
+	if d.Type == "error" {
+		return true
+	}
+	v, err := parser.ParseExpr(d.value)
+	if err != nil {
+		return false
+	}
+	if call, ok := v.(*ast.CallExpr); ok {
+		if se, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if ident, ok := se.X.(*ast.Ident); ok {
+				fn := ident.Name + "." + se.Sel.Name
+				return fn == "errors.New" || fn == "fmt.Errorf"
+			}
+		}
+	}
+	return false
+}
+
+// checkSentinelErrors flags exported error vars whose names don't begin with "Err"
+func checkSentinelErrors(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, d := range p.c.Vars {
+			if d.Exported() && isSentinelError(d) && !strings.HasPrefix(d.Name(), "Err") {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: d.ID(),
+					Text:     errorSentinelPrefix,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkErrorUnwrap flags error structs having a field of type error but no Unwrap method
+func checkErrorUnwrap(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for name := range m.exportedErrorTypes(p) {
+			s, ok := p.c.Structs[name]
+			if !ok {
+				continue
+			}
+			wraps := false
+			for _, t := range s.fields {
+				wraps = wraps || t == "error"
+			}
+			for _, t := range s.AnonymousFields {
+				wraps = wraps || t == "error"
+			}
+			if !wraps {
+				continue
+			}
+			unwraps := false
+			for _, fn := range p.c.findMethods(name) {
+				unwraps = unwraps || fn.Name() == "Unwrap"
+			}
+			if !unwraps {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: s.ID(),
+					Text:     errorMissingUnwrap,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
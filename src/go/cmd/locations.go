@@ -0,0 +1,102 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// locationsFileSuffix completes the name of the sidecar file mapping a review's LineIDs to source
+// locations e.g. "azblob.locations.json" for the review "azblob.json"
+const locationsFileSuffix = ".locations.json"
+
+// SourceLocation is the position in source of the declaration a ReviewLine represents
+type SourceLocation struct {
+	Column int `json:"column"`
+	// File is the path of the source file relative to the module's root directory, with
+	// forward slashes on all platforms
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+func (l SourceLocation) String() string {
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
+// in returns the location with its file path joined to dir, the directory the path is relative to.
+// This is useful for printing paths relative to the working directory, as editors and terminals expect.
+func (l SourceLocation) in(dir string) SourceLocation {
+	if !filepath.IsAbs(l.File) {
+		l.File = filepath.ToSlash(filepath.Join(dir, l.File))
+	}
+	return l
+}
+
+// location returns the source location of the line whose LineID is id. Like position, it falls
+// back to the nearest enclosing declaration when id has no position of its own.
+func (r *Review) location(id string) (SourceLocation, bool) {
+	pos, ok := r.position(id)
+	if !ok {
+		return SourceLocation{}, false
+	}
+	file := pos.Filename
+	if rel, err := filepath.Rel(r.path, pos.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return SourceLocation{Column: pos.Column, File: filepath.ToSlash(file), Line: pos.Line}, true
+}
+
+// locations maps the LineIDs of the given review's lines to their source locations
+func (r *Review) locations(cf CodeFile) map[string]SourceLocation {
+	locs := map[string]SourceLocation{}
+	forAll(cf.ReviewLines, func(ln ReviewLine) {
+		if ln.LineID == "" {
+			return
+		}
+		if loc, ok := r.location(ln.LineID); ok {
+			locs[ln.LineID] = loc
+		}
+	})
+	return locs
+}
+
+// writeLocations writes a sidecar file for cf, the review r generated, to outputDir. The file,
+// named for the review e.g. "azblob.locations.json", maps the LineID of each line in the review
+// to the source location of the line's declaration.
+func (r *Review) writeLocations(cf CodeFile, outputDir string) error {
+	b, err := json.MarshalIndent(r.locations(cf), "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, cf.Name+locationsFileSuffix), b, 0644)
+}
+
+// Locate returns the source location of the line whose LineID is id in a review of the module
+// in pkgDir. The location's file path is relative to pkgDir.
+func Locate(pkgDir, id string) (SourceLocation, error) {
+	r, err := NewReview(pkgDir, nil)
+	if err != nil {
+		return SourceLocation{}, err
+	}
+	cf, err := r.Review()
+	if err != nil {
+		return SourceLocation{}, err
+	}
+	found := false
+	forAll(cf.ReviewLines, func(ln ReviewLine) {
+		found = found || ln.LineID == id
+	})
+	if !found {
+		return SourceLocation{}, fmt.Errorf("review of %s has no line %q", cf.Name, id)
+	}
+	loc, ok := r.location(id)
+	if !ok {
+		return SourceLocation{}, fmt.Errorf("no source location for %q", id)
+	}
+	return loc, nil
+}
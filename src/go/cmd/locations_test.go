@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocations(t *testing.T) {
+	r, err := NewReview(filepath.Clean("testdata/test_directives"), nil)
+	require.NoError(t, err)
+	cf, err := r.Review()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, r.writeLocations(cf, dir))
+	b, err := os.ReadFile(filepath.Join(dir, "test_directives"+locationsFileSuffix))
+	require.NoError(t, err)
+	locs := map[string]SourceLocation{}
+	require.NoError(t, json.Unmarshal(b, &locs))
+
+	forAll(cf.ReviewLines, func(ln ReviewLine) {
+		if ln.LineID != "" {
+			require.Contains(t, locs, ln.LineID)
+			require.Equal(t, "test.go", locs[ln.LineID].File)
+		}
+	})
+	dir = filepath.Clean("testdata/test_directives")
+	for id, name := range map[string]string{
+		"test_directives":                "",
+		"test_directives.Visible":        "Visible",
+		"test_directives.Visible-Secret": "Visible.Secret",
+		"test_directives-NewVisible":     "NewVisible",
+	} {
+		require.Equal(t, declLocation(t, dir, "test.go", name), locs[id], id)
+	}
+}
+
+// declLocation returns the location of the named declaration in the source file at path, which
+// is relative to dir, by parsing the file. Methods and struct fields are named for their type e.g.
+// "Pager.NextPage" and "Options.Retry", and the empty name is the package clause.
+func declLocation(t *testing.T, dir, path, name string) SourceLocation {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(dir, path), nil, 0)
+	require.NoError(t, err)
+	location := func(pos token.Pos) SourceLocation {
+		p := fset.Position(pos)
+		return SourceLocation{Column: p.Column, File: filepath.ToSlash(path), Line: p.Line}
+	}
+	if name == "" {
+		return location(f.Package)
+	}
+	typeName, fieldName, _ := strings.Cut(name, ".")
+	var field token.Pos
+	for _, decl := range f.Decls {
+		var pos token.Pos
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			n := d.Name.Name
+			if d.Recv != nil {
+				recv := d.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				switch r := recv.(type) {
+				case *ast.IndexExpr:
+					recv = r.X
+				case *ast.IndexListExpr:
+					recv = r.X
+				}
+				n = recv.(*ast.Ident).Name + "." + n
+			}
+			if n == name {
+				pos = d.Pos()
+			}
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				ts, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if ts.Name.Name == name {
+					pos = ts.Pos()
+				} else if st, ok := ts.Type.(*ast.StructType); ok && ts.Name.Name == typeName {
+					for _, fd := range st.Fields.List {
+						for _, n := range fd.Names {
+							if n.Name == fieldName {
+								field = n.Pos()
+							}
+						}
+					}
+				}
+			}
+		}
+		if pos.IsValid() {
+			return location(pos)
+		}
+	}
+	if field.IsValid() {
+		return location(field)
+	}
+	t.Fatalf("%s has no declaration %q", path, name)
+	return SourceLocation{}
+}
+
+func TestLocate(t *testing.T) {
+	dir := filepath.Clean("testdata/test_output")
+	loc, err := Locate(dir, "test_output/subpackage-(p *Pager[T]) NextPage")
+	require.NoError(t, err)
+	expected := declLocation(t, dir, "subpackage/subpackage.go", "Pager.NextPage")
+	require.Equal(t, expected, loc)
+	require.Equal(t, fmt.Sprintf("subpackage/subpackage.go:%d:1", expected.Line), loc.String())
+
+	// an alias locates to its declaration in the reviewed module, not the aliased type's definition
+	loc, err = Locate(dir, "test_output.StructA")
+	require.NoError(t, err)
+	require.Equal(t, declLocation(t, dir, "test.go", "StructA"), loc)
+
+	_, err = Locate(dir, "test_output.Nonexistent")
+	require.Error(t, err)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	modelFieldTypes = "Models declare this field with different types: "
+	modelPointer    = "Model fields having scalar types should be pointers"
+	modelTimeFormat = "The model should marshal time.Time fields with a helper type that determines their format"
+)
+
+const (
+	// modelsFileName is the file in which generated packages define models
+	modelsFileName = "models.go"
+	timeType       = "time.Time"
+)
+
+func init() {
+	RegisterRule(NewRule("GO012", helpModels, checkModelPointers))
+	RegisterRule(NewRule("GO013", helpModels, checkModelTimes))
+	RegisterRule(NewRule("GO014", helpModels, checkModelFieldTypes))
+}
+
+// scalarTypes are the predeclared types that Azure SDK models represent as pointers, so that
+// marshaling can distinguish a zero value from an absent one
+var scalarTypes = []string{
+	"bool", "byte", "complex128", "complex64", "float32", "float64", "int", "int16", "int32", "int64", "int8",
+	"rune", "string", "uint", "uint16", "uint32", "uint64", "uint8", "uintptr",
+}
+
+// models returns the names of a package's exported model types, which are structs defined in
+// models.go or having custom JSON marshaling. It omits aliases for models of the module's
+// reviewed packages because rules check those at their definitions.
+func (m *Module) models(p *Pkg) []string {
+	c := p.c
+	names := []string{}
+	for name, s := range c.Structs {
+		if !s.Exported() || m.reviewedAlias(p, name) {
+			continue
+		}
+		if filepath.Base(s.pos.Filename) == modelsFileName || hasJSONMethods(c, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasJSONMethods returns true when the named type has a MarshalJSON or UnmarshalJSON method
+func hasJSONMethods(c content, name string) bool {
+	for _, fn := range c.findMethods(name) {
+		if fn.Name() == "MarshalJSON" || fn.Name() == "UnmarshalJSON" {
+			return true
+		}
+	}
+	return false
+}
+
+// isScalar returns true when t is a predeclared scalar type, time.Time, or a type defined in
+// the package having either as its underlying type e.g. an enum
+func isScalar(c content, t string) bool {
+	if t == timeType || slices.Contains(scalarTypes, t) {
+		return true
+	}
+	if st, ok := c.SimpleTypes[t]; ok {
+		u := stripNavigators(st.underlyingType)
+		return u == timeType || slices.Contains(scalarTypes, u)
+	}
+	return false
+}
+
+// checkModelPointers flags model fields having non-pointer scalar types
+func checkModelPointers(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, name := range m.models(p) {
+			s := p.c.Structs[name]
+			for field, t := range s.fields {
+				if exportedFieldRgx.MatchString(field) && isScalar(p.c, stripNavigators(t)) {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.id + "-" + field,
+						Text:     modelPointer,
+					})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkModelTimes flags time.Time fields of models which can't control the fields' format. That
+// requires custom JSON marshaling and a helper type in the package, such as "type dateTimeRFC3339 time.Time".
+func checkModelTimes(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		helper := false
+		for _, st := range p.c.SimpleTypes {
+			if stripNavigators(st.underlyingType) == timeType {
+				helper = true
+				break
+			}
+		}
+		for _, name := range m.models(p) {
+			if helper && hasJSONMethods(p.c, name) {
+				continue
+			}
+			s := p.c.Structs[name]
+			for field, t := range s.fields {
+				if !exportedFieldRgx.MatchString(field) {
+					continue
+				}
+				if strings.TrimLeft(stripNavigators(t), "*[]") == timeType {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.id + "-" + field,
+						Text:     modelTimeFormat,
+					})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkModelFieldTypes flags fields having the same name but different types in the module's
+// models. Each diagnostic names the packages declaring each type, so drift between packages is
+// as clear as drift within one. It compares only fields whose types are predeclared or defined
+// outside the module, because the module's types are often specific to a model, like the
+// "Properties" types of ARM resources.
+func checkModelFieldTypes(m *Module) []CodeDiagnostic {
+	type modelField struct {
+		id, pkg string
+	}
+	// field name => type => fields having that name and type
+	fields := map[string]map[string][]modelField{}
+	for _, p := range m.reviewedPackages() {
+		for _, name := range m.models(p) {
+			s := p.c.Structs[name]
+			for field, t := range s.fields {
+				if !exportedFieldRgx.MatchString(field) || navigatorTypeRgx.MatchString(t) {
+					continue
+				}
+				if fields[field] == nil {
+					fields[field] = map[string][]modelField{}
+				}
+				fields[field][t] = append(fields[field][t], modelField{id: s.id + "-" + field, pkg: p.Name()})
+			}
+		}
+	}
+	diagnostics := []CodeDiagnostic{}
+	for _, types := range fields {
+		if len(types) < 2 {
+			continue
+		}
+		// describe each type with the packages declaring it e.g. "*int32 (azblob, azblob/container)"
+		descriptions := make([]string, 0, len(types))
+		for t, mfs := range types {
+			pkgs := []string{}
+			for _, mf := range mfs {
+				if !slices.Contains(pkgs, mf.pkg) {
+					pkgs = append(pkgs, mf.pkg)
+				}
+			}
+			sort.Strings(pkgs)
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", stripNavigators(t), strings.Join(pkgs, ", ")))
+		}
+		sort.Strings(descriptions)
+		for _, mfs := range types {
+			for _, mf := range mfs {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: mf.id,
+					Text:     modelFieldTypes + strings.Join(descriptions, ", "),
+				})
+			}
+		}
+	}
+	return diagnostics
+}
@@ -6,12 +6,13 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"go/ast"
+	"io"
 	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"golang.org/x/mod/modfile"
@@ -35,6 +36,9 @@ type Module struct {
 	Name string
 	// Packages maps import paths to the module's Packages
 	Packages map[string]*Pkg
+
+	// config configures the module's diagnostics. It's set only for the module under review.
+	config Config
 }
 
 // getPackageNameFromModPath gets the API review name for the module at modPath
@@ -52,9 +56,8 @@ func getPackageNameFromModPath(modPath string) string {
 	return modPath
 }
 
-// NewModule indexes a module's ASTs
-func NewModule(dir string) (*Module, error) {
-	fmt.Println("Indexing", dir)
+// NewModule indexes a module's ASTs, writing warnings about source the parser doesn't handle to log
+func NewModule(dir string, log io.Writer) (*Module, error) {
 	mf, err := parseModFile(dir)
 	if err != nil {
 		return nil, err
@@ -89,7 +92,7 @@ func NewModule(dir string) (*Module, error) {
 					return filepath.SkipDir
 				}
 			}
-			p, err := NewPkg(path, m.ModFile.Module.Mod.Path, dir)
+			p, err := NewPkg(path, m.ModFile.Module.Mod.Path, dir, log)
 			if err == nil {
 				m.Packages[baseImportPath+p.Name()] = p
 			} else if !errors.Is(err, ErrNoPackages) {
@@ -133,43 +136,28 @@ func NewModule(dir string) (*Module, error) {
 	return &m, nil
 }
 
-// returns the type name for the specified struct field.
-// if the field can be ignored, an empty string is returned.
-func unwrapStructFieldTypeName(field *ast.Field) string {
-	if field.Names != nil && !field.Names[0].IsExported() {
-		// field isn't exported so skip it
-		return ""
-	}
-
-	// start with the field expression
-	exp := field.Type
-
-	// if it's an array, get the element expression.
-	// current codegen doesn't support *[]Type so no need to handle it.
-	if at, ok := exp.(*ast.ArrayType); ok {
-		// FieldName []FieldType
-		// FieldName []*FieldType
-		exp = at.Elt
-	}
-
-	// from here we either have a pointer-to-type or type
-	var ident *ast.Ident
-	if se, ok := exp.(*ast.StarExpr); ok {
-		// FieldName *FieldType
-		ident, _ = se.X.(*ast.Ident)
-	} else {
-		// FieldName FieldType
-		ident, _ = exp.(*ast.Ident)
+// reviewedPackages returns the module's packages that appear in its review, sorted by import path.
+// This excludes empty packages and, except in a module named "internal", internal packages.
+func (m *Module) reviewedPackages() []*Pkg {
+	names := []string{}
+	for name, p := range m.Packages {
+		// we use a prefixed path separator so that we can handle the "internal" module.
+		//  internal/dig
+		//  internal/errorinfo
+		//  etc.
+		// for other modules, we skip /internal subdirectories
+		//  azcore/internal/...
+		if strings.Contains(p.relName, "/internal") || p.c.isEmpty() {
+			continue
+		}
+		names = append(names, name)
 	}
-
-	// !IsExported() is a hacky way to ignore primitive types
-	// FieldName bool
-	if ident == nil || !ident.IsExported() {
-		return ""
+	sort.Strings(names)
+	pkgs := make([]*Pkg, 0, len(names))
+	for _, name := range names {
+		pkgs = append(pkgs, m.Packages[name])
 	}
-
-	// returns FieldType
-	return ident.Name
+	return pkgs
 }
 
 func hoistMethodsForType(pkg *Pkg, typeName string, target *Pkg) {
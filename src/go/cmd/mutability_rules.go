@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"go/ast"
+	"go/parser"
+	"slices"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	mutableVar        = "Exported variables shouldn't be mutable. Consider a func returning a new value instead of this "
+	syncValueExposed  = "Exported structs shouldn't expose synchronization primitives: "
+	syncValueHeld     = "Copying this struct copies the synchronization primitive it holds, which go vet's copylocks check reports: "
+	syncValueReturned = "Returning a value holding a synchronization primitive copies it: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO021", "", checkMutableVars))
+	RegisterRule(NewRule("GO022", helpSyncPackage, checkSyncValues))
+}
+
+// syncTypes are synchronization primitives which mustn't be copied after first use
+var syncTypes = []string{"sync.Mutex", "sync.RWMutex", "sync.WaitGroup"}
+
+// mutableKind returns "map", "slice" or "pointer" when the declaration's value is a map, slice or
+// pointer to a named type other than an interface or a type defined in the package as a simple type.
+// Otherwise, it returns an empty string.
+func mutableKind(c content, d Declaration) string {
+	var t ast.Expr
+	if d.Type != "" && d.Type != skip {
+		t, _ = parser.ParseExpr(stripNavigators(d.Type))
+	} else if v, err := parser.ParseExpr(d.value); err == nil {
+		// the declaration has no explicit type, so infer it from the value where possible
+		switch x := v.(type) {
+		case *ast.CompositeLit:
+			// var Defaults = map[string]string{}
+			t = x.Type
+		case *ast.CallExpr:
+			// var Cache = make(map[string]string)
+			if ident, ok := x.Fun.(*ast.Ident); ok && ident.Name == "make" && len(x.Args) > 0 {
+				t = x.Args[0]
+			}
+		case *ast.UnaryExpr:
+			// var Default = &Options{}
+			if cl, ok := x.X.(*ast.CompositeLit); ok && cl.Type != nil {
+				t = &ast.StarExpr{X: cl.Type}
+			}
+		}
+	}
+	switch x := t.(type) {
+	case *ast.ArrayType:
+		if x.Len == nil {
+			return "slice"
+		}
+	case *ast.MapType:
+		return "map"
+	case *ast.StarExpr:
+		switch elem := x.X.(type) {
+		case *ast.Ident:
+			if _, ok := c.Structs[elem.Name]; ok {
+				return "pointer"
+			}
+		case *ast.SelectorExpr:
+			// a type from another package, most likely a struct e.g. *http.Client
+			return "pointer"
+		}
+	}
+	return ""
+}
+
+// checkMutableVars flags exported vars holding maps, slices or pointers, which any code could modify
+func checkMutableVars(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, d := range p.c.Vars {
+			if !d.Exported() {
+				continue
+			}
+			if kind := mutableKind(p.c, d); kind != "" {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: d.ID(),
+					Text:     mutableVar + kind,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// syncFields returns the synchronization primitives a struct holds by value, mapping the names
+// of fields to their types. Embedded primitives are keyed by their type.
+func (s Struct) syncFields() map[string]string {
+	fields := map[string]string{}
+	for name, t := range s.fields {
+		if slices.Contains(syncTypes, t) {
+			fields[name] = t
+		}
+	}
+	for _, t := range s.AnonymousFields {
+		if slices.Contains(syncTypes, t) {
+			fields[t] = t
+		}
+	}
+	return fields
+}
+
+// checkSyncValues flags exported structs holding synchronization primitives by value, whether they
+// expose them by embedding them or in exported fields or hold them in unexported fields, and
+// exported funcs returning by value structs holding them
+func checkSyncValues(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, s := range p.c.Structs {
+			if !s.Exported() || m.reviewedAlias(p, s.Name()) {
+				continue
+			}
+			for name, t := range s.syncFields() {
+				if name == t {
+					// embedding promotes the primitive's methods e.g. Lock and Unlock
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.ID(),
+						Text:     syncValueExposed + t,
+					})
+				} else if exportedFieldRgx.MatchString(name) {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.ID() + "-" + name,
+						Text:     syncValueExposed + t,
+					})
+				} else {
+					// the review doesn't show unexported fields, so target the struct
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: s.ID(),
+						Text:     syncValueHeld + name + " " + t,
+					})
+				}
+			}
+		}
+		for _, fn := range p.c.Funcs {
+			if !fn.Exported() || m.reviewedAlias(p, fn.receiverBaseType) {
+				continue
+			}
+			for _, r := range fn.Returns {
+				// "Widget" or "Widget[T]" but not "*Widget"
+				name, _, _ := strings.Cut(stripNavigators(r), "[")
+				s, ok := p.c.Structs[name]
+				if !ok {
+					continue
+				}
+				types := []string{}
+				for _, t := range s.syncFields() {
+					types = append(types, t)
+				}
+				if len(types) > 0 {
+					slices.Sort(types)
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: fn.ID(),
+						Text:     syncValueReturned + name + " holds a " + types[0],
+					})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,261 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// diagnostic messages
+const (
+	namingEnumPrefix = "Constants of this type should have names beginning with "
+	namingInitialism = "Initialisms should have a consistent case: "
+	namingStutter    = "Name repeats the package name "
+	namingUnderscore = "Exported names shouldn't contain underscores"
+)
+
+func init() {
+	RegisterRule(NewRule("GO008", helpCodeReviewComments, checkInitialisms))
+	RegisterRule(NewRule("GO009", helpEffectiveGo, checkStutter))
+	RegisterRule(NewRule("GO010", helpEffectiveGo, checkUnderscores))
+	RegisterRule(NewRule("GO011", helpEnums, checkEnumPrefixes))
+}
+
+// initialisms Go code conventionally writes in a consistent case e.g. "ID" or "id" but not "Id"
+var initialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS",
+	"QPS", "RAM", "RHS", "RPC", "SAS", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// identifier is an exported name in a package's API
+type identifier struct {
+	// id is the LineID of the identifier's declaration
+	id string
+	// kind of declaration e.g. "type"
+	kind string
+	name string
+}
+
+// identifier kinds
+const (
+	identifierConst  = "const"
+	identifierField  = "field"
+	identifierFunc   = "func"
+	identifierMethod = "method"
+	identifierType   = "type"
+	identifierVar    = "var"
+)
+
+// exportedIdentifiers returns the exported names declared by a package's content. It omits aliases
+// for types of the module's reviewed packages, and their methods, because rules check those at
+// their definitions.
+func (m *Module) exportedIdentifiers(p *Pkg) []identifier {
+	c := p.c
+	ids := []identifier{}
+	for _, d := range c.Consts {
+		if d.Exported() {
+			ids = append(ids, identifier{id: d.ID(), kind: identifierConst, name: d.Name()})
+		}
+	}
+	for _, d := range c.Vars {
+		if d.Exported() {
+			ids = append(ids, identifier{id: d.ID(), kind: identifierVar, name: d.Name()})
+		}
+	}
+	for _, fn := range c.Funcs {
+		if fn.Exported() && !m.reviewedAlias(p, fn.receiverBaseType) {
+			kind := identifierFunc
+			if fn.ReceiverType != "" {
+				kind = identifierMethod
+			}
+			ids = append(ids, identifier{id: fn.ID(), kind: kind, name: fn.Name()})
+		}
+	}
+	for _, in := range c.Interfaces {
+		if !in.Exported() || m.reviewedAlias(p, in.Name()) {
+			continue
+		}
+		ids = append(ids, identifier{id: in.ID(), kind: identifierType, name: in.Name()})
+		for name, m := range in.methods {
+			if m.Exported() {
+				ids = append(ids, identifier{id: in.ID() + "-" + name, kind: identifierMethod, name: name})
+			}
+		}
+	}
+	for _, t := range c.SimpleTypes {
+		if t.Exported() && !m.reviewedAlias(p, t.Name()) {
+			ids = append(ids, identifier{id: t.ID(), kind: identifierType, name: t.Name()})
+		}
+	}
+	for _, s := range c.Structs {
+		if s.Exported() && !m.reviewedAlias(p, s.Name()) {
+			ids = append(ids, identifier{id: s.ID(), kind: identifierType, name: s.Name()})
+			ids = append(ids, s.exportedFields()...)
+		}
+	}
+	return ids
+}
+
+// exportedFields returns identifiers for the struct's exported fields, including those of
+// fields having anonymous struct types
+func (s Struct) exportedFields() []identifier {
+	ids := []identifier{}
+	for name := range s.fields {
+		if !exportedFieldRgx.MatchString(name) {
+			continue
+		}
+		ids = append(ids, identifier{id: s.id + "-" + name, kind: identifierField, name: name})
+		if nested, ok := s.nested[name]; ok {
+			ids = append(ids, nested.exportedFields()...)
+		}
+	}
+	return ids
+}
+
+// splitWords splits an identifier into words at case changes and underscores, keeping
+// initialisms together. For example, "HTTPClientIDs" becomes "HTTP", "Client" and "IDs".
+func splitWords(s string) []string {
+	words := []string{}
+	runes := []rune(s)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i == start {
+			continue
+		}
+		prev, cur := runes[i-1], runes[i]
+		split := false
+		switch {
+		case (unicode.IsLower(prev) || unicode.IsDigit(prev)) && unicode.IsUpper(cur):
+			// "fooBar" or "utf8Bar"
+			split = true
+		case unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// "HTTPClient" splits before "C", however "IDs" is a plural initialism
+			plural := runes[i+1] == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2]))
+			split = !plural
+		}
+		if split {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// fixInitialism returns the conventional form of a word that's an initialism in mixed case,
+// for example "ID" for "Id" or "IDs" for "Ids". It returns an empty string for other words.
+func fixInitialism(w string) string {
+	base, suffix := w, ""
+	if len(w) > 2 && strings.HasSuffix(w, "s") {
+		base, suffix = w[:len(w)-1], "s"
+	}
+	for _, b := range []string{w, base} {
+		upper := strings.ToUpper(b)
+		if b != upper && b != strings.ToLower(b) && slices.Contains(initialisms, upper) {
+			if b == base {
+				return upper + suffix
+			}
+			return upper
+		}
+	}
+	return ""
+}
+
+// checkInitialisms flags exported names having initialisms in mixed case e.g. "Id" or "Url"
+func checkInitialisms(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, ident := range m.exportedIdentifiers(p) {
+			for _, w := range splitWords(ident.name) {
+				if fixed := fixInitialism(w); fixed != "" {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelWarning,
+						TargetID: ident.id,
+						Text:     namingInitialism + w + " should be " + fixed,
+					})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkStutter flags package-level names beginning with the package's name e.g. "azblob.AzblobClient".
+// The package's name is the one its package clause declares, which needn't be its directory's name.
+func checkStutter(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		pkgName := strings.ToLower(p.p.Name)
+		for _, ident := range m.exportedIdentifiers(p) {
+			switch ident.kind {
+			case identifierConst, identifierFunc, identifierType, identifierVar:
+			default:
+				// fields and methods are qualified by their types, not the package name
+				continue
+			}
+			rest, found := strings.CutPrefix(strings.ToLower(ident.name), pkgName)
+			// a name equal to the package name doesn't stutter, nor does one merely beginning
+			// with the same letters e.g. "Blobs" in package "blob"
+			if found && rest != "" && unicode.IsUpper(rune(ident.name[len(pkgName)])) {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: ident.id,
+					Text:     namingStutter + p.p.Name,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkUnderscores flags exported names containing underscores
+func checkUnderscores(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, ident := range m.exportedIdentifiers(p) {
+			if strings.Contains(ident.name, "_") {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: ident.id,
+					Text:     namingUnderscore,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkEnumPrefixes flags typed constants whose names don't begin with the name of their type,
+// which should be a type defined in the same package e.g. "const BlobTypeBlock BlobType"
+func checkEnumPrefixes(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, d := range p.c.Consts {
+			t := stripNavigators(d.Type)
+			if _, ok := p.c.SimpleTypes[t]; !ok || !d.Exported() {
+				continue
+			}
+			if !strings.HasPrefix(d.Name(), t) {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelWarning,
+					TargetID: d.ID(),
+					Text:     namingEnumPrefix + t,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,215 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	paramNameInconsistent = "Parameters of this type are usually named differently: "
+	paramTypeInconsistent = "Parameters of this name usually have a different type: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO028", helpCodeReviewComments, checkParamNames))
+	RegisterRule(NewRule("GO029", helpCodeReviewComments, checkParamTypes))
+}
+
+// param is a parameter of an exported func or interface method
+type param struct {
+	// id is the LineID of the func or method
+	id   string
+	name string
+	// shared indicates the func has other parameters of the same type, which must have other names
+	shared bool
+	// t is the parameter's type including navigators, which distinguish same-named types
+	// of different packages
+	t string
+}
+
+// params returns the named parameters of the exported funcs and interface methods of a module's
+// reviewed packages, omitting those whose types involve type parameters and those of aliases for
+// types of reviewed packages, which would otherwise count twice
+func params(m *Module) []param {
+	ps := []param{}
+	add := func(id string, fn Func) {
+		typeParams := append(append([]string{}, fn.typeParamNames...), fn.receiverTypeParams...)
+		counts := map[string]int{}
+		for _, t := range fn.paramTypes {
+			counts[t]++
+		}
+		for i, name := range fn.paramNames {
+			if name == "" || name == "_" {
+				continue
+			}
+			generic := false
+			for n := range typeNames(fn.paramTypes[i]) {
+				generic = generic || slices.Contains(typeParams, n)
+			}
+			if !generic {
+				ps = append(ps, param{id: id, name: name, shared: counts[fn.paramTypes[i]] > 1, t: fn.paramTypes[i]})
+			}
+		}
+	}
+	for _, p := range m.reviewedPackages() {
+		for _, fn := range p.c.Funcs {
+			if fn.Exported() && !m.reviewedAlias(p, fn.receiverBaseType) {
+				add(fn.ID(), fn)
+			}
+		}
+		for _, in := range p.c.Interfaces {
+			if !in.Exported() || m.reviewedAlias(p, in.Name()) {
+				continue
+			}
+			for name, fn := range in.methods {
+				if fn.Exported() {
+					add(in.ID()+"-"+name, fn)
+				}
+			}
+		}
+	}
+	return ps
+}
+
+// canonical returns the most common key of counts, preferring the alphabetically first of equally common keys
+func canonical(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys[0]
+}
+
+// abbreviates returns whether short abbreviates long, meaning short comprises the words of long
+// in order, each shortened to some of its letters beginning with the first e.g. "rgName"
+// abbreviates "resourceGroupName" and "ctx" abbreviates "context"
+func abbreviates(short, long string) bool {
+	if len(short) >= len(long) {
+		return false
+	}
+	words := []string{}
+	for _, w := range splitWords(long) {
+		words = append(words, strings.ToLower(w))
+	}
+	// match returns whether s abbreviates words
+	var match func(s string, words []string) bool
+	// rest returns whether s abbreviates the remainder w of a word whose first letter has matched,
+	// followed by words
+	var rest func(s, w string, words []string) bool
+	match = func(s string, words []string) bool {
+		if len(words) == 0 {
+			return s == ""
+		}
+		return s != "" && s[0] == words[0][0] && rest(s[1:], words[0][1:], words[1:])
+	}
+	rest = func(s, w string, words []string) bool {
+		if match(s, words) {
+			return true
+		}
+		for i := 0; s != "" && i < len(w); i++ {
+			if w[i] == s[0] && rest(s[1:], w[i+1:], words) {
+				return true
+			}
+		}
+		return false
+	}
+	return match(strings.ToLower(short), words)
+}
+
+// checkParamNames flags parameters named differently than most parameters of the same type.
+// Types composed only of predeclared types such as string have many roles, so for those it
+// compares only names that abbreviate one another, such as "rgName" and "resourceGroupName".
+func checkParamNames(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	// type => name => count
+	names := map[string]map[string]int{}
+	ps := []param{}
+	for _, p := range params(m) {
+		if p.shared {
+			continue
+		}
+		ps = append(ps, p)
+		if names[p.t] == nil {
+			names[p.t] = map[string]int{}
+		}
+		names[p.t][p.name]++
+	}
+	for _, p := range ps {
+		counts := names[p.t]
+		if predeclaredOnly(p.t) {
+			counts = map[string]int{}
+			for name, n := range names[p.t] {
+				if name == p.name || abbreviates(name, p.name) || abbreviates(p.name, name) {
+					counts[name] = n
+				}
+			}
+		}
+		if len(counts) < 2 {
+			continue
+		}
+		if c := canonical(counts); p.name != c {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: p.id,
+				Text:     paramNameInconsistent + fmt.Sprintf("%s %s should be %s %s", p.name, stripNavigators(p.t), c, stripNavigators(p.t)),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// checkParamTypes flags parameters having a different type than most parameters of the same name.
+// It ignores types defined in the module because those are often specific to a method, like options.
+func checkParamTypes(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	// name => type => count
+	ts := map[string]map[string]int{}
+	ps := []param{}
+	for _, p := range params(m) {
+		if strings.Contains(p.t, "<") {
+			// the type has a navigator, so the module defines it
+			continue
+		}
+		ps = append(ps, p)
+		if ts[p.name] == nil {
+			ts[p.name] = map[string]int{}
+		}
+		ts[p.name][p.t]++
+	}
+	for _, p := range ps {
+		if len(ts[p.name]) < 2 {
+			continue
+		}
+		if c := canonical(ts[p.name]); p.t != c {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: p.id,
+				Text:     paramTypeInconsistent + fmt.Sprintf("%s %s should be %s %s", p.name, p.t, p.name, c),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// predeclaredOnly returns whether the type expression t refers only to predeclared types e.g. "[]string"
+func predeclaredOnly(t string) bool {
+	for n := range typeNames(t) {
+		if types.Universe.Lookup(n) == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -9,22 +9,22 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
-	"unicode"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"golang.org/x/mod/module"
 )
 
 // diagnostic messages
 const (
-	aliasFor               = "Alias for "
-	missingAliasFor        = "missing alias for nested type "
-	embedsUnexportedStruct = "Anonymously embeds unexported struct "
-	sealedInterface        = "Applications can't implement this interface"
+	aliasFor         = "Alias for "
+	unknownDirective = "Unknown apiview directive "
 )
 
 var ErrNoPackages = errors.New("no packages found")
@@ -34,10 +34,21 @@ type Pkg struct {
 	modulePath  string
 	c           content
 	diagnostics []CodeDiagnostic
-	files       map[string][]byte
-	fs          *token.FileSet
-	p           *ast.Package
-	relName     string
+	// directives are the apiview directives found in the package's comments
+	directives []directive
+	// docs maps the LineIDs of declarations to their doc comments
+	docs  map[string]string
+	files map[string][]byte
+	fs    *token.FileSet
+	// imports maps the names the package's files give imported packages to those packages'
+	// import paths e.g. "runtime" => "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	imports map[string]string
+	// log receives warnings about source the parser doesn't handle
+	log io.Writer
+	p   *ast.Package
+	// positions maps the LineIDs of declarations to their positions in source
+	positions map[string]token.Position
+	relName   string
 
 	// TypeAliases are types exported from this package but defined in another. For
 	// example, package "azcore" may export TokenCredential from azcore/internal/shared
@@ -55,7 +66,8 @@ type Pkg struct {
 //   - dir is the directory containing the package
 //   - modulePath is the import path of the module containing the package
 //   - moduleRoot is the root directory of the module on disk i.e., the directory containing its go.mod
-func NewPkg(dir, modulePath, moduleRoot string) (*Pkg, error) {
+//   - log receives warnings about source the parser doesn't handle
+func NewPkg(dir, modulePath, moduleRoot string, log io.Writer) (*Pkg, error) {
 	// ensure that all directories are using the same path separator
 	// character else the below call to strings.Cut() will fail
 	dir = filepath.ToSlash(dir)
@@ -65,6 +77,10 @@ func NewPkg(dir, modulePath, moduleRoot string) (*Pkg, error) {
 		modulePath:  modulePath,
 		c:           newContent(),
 		diagnostics: []CodeDiagnostic{},
+		docs:        map[string]string{},
+		imports:     map[string]string{},
+		log:         log,
+		positions:   map[string]token.Position{},
 		types:       map[string]typeDef{},
 	}
 	modulePathWithoutVersion := strings.TrimSuffix(versionReg.ReplaceAllString(modulePath, "/"), "/")
@@ -79,7 +95,7 @@ func NewPkg(dir, modulePath, moduleRoot string) (*Pkg, error) {
 	packages, err := parser.ParseDir(pk.fs, dir, func(f os.FileInfo) bool {
 		// exclude test files
 		return !strings.HasSuffix(f.Name(), "_test.go")
-	}, 0)
+	}, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +114,11 @@ func NewPkg(dir, modulePath, moduleRoot string) (*Pkg, error) {
 	panic("failed to load package")
 }
 
+// warn writes a warning about source the parser doesn't handle to the package's log
+func (p Pkg) warn(format string, args ...any) {
+	fmt.Fprintf(p.log, format+"\n", args...)
+}
+
 // Name returns the package's name relative to its module, for example "azcore/runtime".
 func (pkg Pkg) Name() string {
 	return pkg.relName
@@ -108,6 +129,38 @@ func (p *Pkg) Index() {
 	for _, f := range p.p.Files {
 		p.indexFile(f)
 	}
+	p.resolveTypeSets()
+}
+
+// resolveTypeSets moves embedded elements naming a non-interface type of this package, such as
+// MyInt in "interface{ MyInt }", to their interface's type set. NewInterface can't distinguish
+// these from embedded interfaces because it sees only the name, and the definition may be in
+// a file not yet indexed.
+//
+// TODO: this doesn't resolve types of other packages e.g. "interface{ other.MyInt }" because
+// their definitions aren't available until the module is indexed.
+func (p *Pkg) resolveTypeSets() {
+	for name, in := range p.c.Interfaces {
+		embedded := []string{}
+		for _, e := range in.embeddedInterfaces {
+			def, ok := p.types[stripNavigators(e)]
+			if !ok {
+				embedded = append(embedded, e)
+				continue
+			}
+			if _, isInterface := def.n.Type.(*ast.InterfaceType); isInterface {
+				embedded = append(embedded, e)
+				continue
+			}
+			in.typeSet = append(in.typeSet, e)
+		}
+		if len(embedded) != len(in.embeddedInterfaces) {
+			in.embeddedInterfaces = embedded
+			in.Constraint = true
+			sort.Strings(in.typeSet)
+			p.c.Interfaces[name] = in
+		}
+	}
 }
 
 func (p *Pkg) indexFile(f *ast.File) {
@@ -121,14 +174,22 @@ func (p *Pkg) indexFile(f *ast.File) {
 			imports[filepath.Base(p)] = p
 		}
 	}
+	maps.Copy(p.imports, imports)
+	// the package's position is its package clause in the first file by name
+	if pos, ok := p.positions[p.Name()]; !ok || p.fs.Position(f.Package).Filename < pos.Filename {
+		p.positions[p.Name()] = p.fs.Position(f.Package)
+	}
 
 	ast.Inspect(f, func(n ast.Node) bool {
 		switch x := n.(type) {
 		case *ast.FuncDecl:
-			p.c.addFunc(*p, x, imports)
+			fn := p.c.addFunc(*p, x, imports)
+			p.addComments(fn.ID(), x.Doc)
+			p.positions[fn.ID()] = fn.pos
 			// children can't be exported, let's not inspect them
 			return false
 		case *ast.GenDecl:
+			p.indexGenDecl(x)
 			if x.Tok == token.CONST || x.Tok == token.VAR {
 				// const or var declaration
 				for _, s := range x.Specs {
@@ -159,14 +220,7 @@ func (p *Pkg) indexFile(f *ast.File) {
 				p.c.addSimpleType(*p, x.Name.Name, p.Name(), txt, imports)
 			case *ast.InterfaceType:
 				p.types[x.Name.Name] = typeDef{n: x, p: p}
-				in := p.c.addInterface(*p, x.Name.Name, p.Name(), t, imports)
-				if in.Sealed {
-					p.diagnostics = append(p.diagnostics, CodeDiagnostic{
-						TargetID: in.ID(),
-						Level:    CodeDiagnosticLevelInfo,
-						Text:     sealedInterface,
-					})
-				}
+				p.c.addInterface(*p, x.Name.Name, p.Name(), t, imports)
 			case *ast.MapType:
 				// "type opValues map[reflect.Type]interface{}"
 				txt := p.getText(t.Pos(), t.End())
@@ -197,20 +251,10 @@ func (p *Pkg) indexFile(f *ast.File) {
 				}
 			case *ast.StructType:
 				p.types[x.Name.Name] = typeDef{n: x, p: p}
-				s := p.c.addStruct(*p, x.Name.Name, p.Name(), x, imports)
-				for _, t := range s.AnonymousFields {
-					// if t contains "." it must be exported
-					if !strings.Contains(t, ".") && unicode.IsLower(rune(t[0])) {
-						p.diagnostics = append(p.diagnostics, CodeDiagnostic{
-							Level:    CodeDiagnosticLevelError,
-							TargetID: s.ID(),
-							Text:     embedsUnexportedStruct + t,
-						})
-					}
-				}
+				p.c.addStruct(*p, x.Name.Name, p.Name(), x, imports)
 			default:
 				txt := p.getText(x.Pos(), x.End())
-				fmt.Printf("unhandled node type %T: %s\n", t, txt)
+				p.warn("unhandled node type %T: %s", t, txt)
 			}
 		}
 		return true
@@ -259,7 +303,7 @@ func (pkg Pkg) translateType(oriVal string, imports map[string]string) string {
 	result := ""
 	for _, ch := range oriVal {
 		switch string(ch) {
-		case "*", "[", "]", " ", "(", ")", "{", "}", ",":
+		case "*", "[", "]", " ", "(", ")", "{", "}", ",", "~", "|":
 			if now != "" {
 				result += pkg.addTypeNavigator(now, imports)
 				now = ""
@@ -352,47 +396,21 @@ func (a *TypeAlias) Resolve(def typeDef) error {
 		case *ast.StructType:
 			t = a.Package.c.addStruct(*def.p, a.Name, a.Package.Name(), def.n, nil)
 			hoistMethodsForType(def.p, a.Name, a.Package)
-			// ensure that all struct field types that are structs are also aliased from this package
-			for _, field := range n.Fields.List {
-				fieldTypeName := unwrapStructFieldTypeName(field)
-				if fieldTypeName == "" {
-					// we can ignore this field
-					continue
-				}
-
-				// ensure that our package exports this type
-				found := false
-				for _, ta := range a.Package.TypeAliases {
-					if ta.Name == fieldTypeName {
-						found = true
-						break
-					}
-				}
-				if found {
-					continue
-				}
-
-				// no alias, add a diagnostic
-				a.Package.diagnostics = append(a.Package.diagnostics, CodeDiagnostic{
-					Level:    CodeDiagnosticLevelError,
-					TargetID: t.ID(),
-					Text:     missingAliasFor + fieldTypeName,
-				})
-			}
 		case *ast.Ident:
 			t = a.Package.c.addSimpleType(*a.Package, a.Name, a.Package.Name(), def.n.Type.(*ast.Ident).Name, nil)
 			hoistMethodsForType(def.p, a.Name, a.Package)
 		default:
-			fmt.Printf("unexpected node type %T\n", def.n.Type)
+			a.Package.warn("unexpected node type %T", def.n.Type)
 			t = a.Package.c.addSimpleType(*a.Package, a.Name, a.Package.Name(), originalName, nil)
 		}
 	}
 
 	if t != nil {
 		a.Package.diagnostics = append(a.Package.diagnostics, CodeDiagnostic{
-			Level:    level,
-			TargetID: t.ID(),
-			Text:     aliasFor + originalName,
+			DiagnosticID: aliasDiagnosticID,
+			Level:        level,
+			TargetID:     t.ID(),
+			Text:         aliasFor + originalName,
 		})
 	}
 	a.resolved = true
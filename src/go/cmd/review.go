@@ -6,9 +6,11 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"go/token"
+	"io"
+	"os"
 	"path/filepath"
 	"slices"
-	"sort"
 	"strings"
 
 	"golang.org/x/mod/module"
@@ -16,31 +18,85 @@ import (
 
 var errExternalModule = errors.New("reviewed module exports a type defined in a different repository")
 
+// StructTagMode determines how struct field tags such as `json:"name,omitempty"` appear in a review
+type StructTagMode string
+
+const (
+	// StructTagsHidden omits struct tags from the review. This is the default.
+	StructTagsHidden StructTagMode = "hidden"
+	// StructTagsSkipDiff shows struct tags but excludes them from diffs between revisions
+	StructTagsSkipDiff StructTagMode = "skipdiff"
+	// StructTagsShown shows struct tags and includes them in diffs between revisions
+	StructTagsShown StructTagMode = "shown"
+)
+
+// Layout determines the order of declarations in a review
+type Layout string
+
+const (
+	// LayoutAlphabetical sorts fields, consts, vars and funcs by name. This is the default.
+	LayoutAlphabetical Layout = "alphabetical"
+	// LayoutSource orders fields, consts, vars and funcs as they appear in source, by file
+	// name and then by position within the file
+	LayoutSource Layout = "source"
+)
+
+// ReviewOptions configures a Review. The zero value is the default configuration.
+type ReviewOptions struct {
+	// Baseline is a previous review of the module. Diagnostics it already has are suppressed
+	// or downgraded according to BaselineMode. Defaults to nil i.e., no baseline.
+	Baseline *CodeFile
+	// BaselineMode determines what happens to diagnostics the baseline already has. Defaults to BaselineSuppress.
+	BaselineMode BaselineMode
+	// Layout determines the order of declarations in the review. Defaults to LayoutAlphabetical.
+	Layout Layout
+	// Log receives progress messages, such as the directories being indexed, and warnings about
+	// the module's configuration and source the parser doesn't handle. Defaults to os.Stdout.
+	Log io.Writer
+	// StructTags determines how struct field tags appear in the review. Defaults to StructTagsHidden.
+	StructTags StructTagMode
+}
+
 // Review represents an apiview review of an Azure SDK for Go module
 type Review struct {
+	// config is the reviewed module's diagnostic configuration
+	config Config
 	// modules maps module paths to Modules implicated in this API review. It
 	// contains only the reviewed module in most cases, however it contains
 	// more when the reviewed module exports types defined in another module.
 	modules map[string]*Module
 	// name of the APIView review e.g. "sdk/azcore"
 	name string
+	// opts configures the review's content
+	opts ReviewOptions
 	// path on disk to the reviewed module
 	path string
 	// reviewed is the module being reviewed
 	reviewed *Module
 }
 
-// NewReview creates a Review for the module at path p
-func NewReview(p string) (*Review, error) {
-	m, err := NewModule(p)
-	if err != nil {
-		return nil, err
-	}
+// NewReview creates a Review for the module at path p. Options may be nil.
+func NewReview(p string, o *ReviewOptions) (*Review, error) {
 	r := &Review{
 		modules: map[string]*Module{},
-		name:    getPackageNameFromModPath(m.ModFile.Module.Mod.Path),
 		path:    p,
 	}
+	if o != nil {
+		r.opts = *o
+	}
+	if r.opts.Log == nil {
+		r.opts.Log = os.Stdout
+	}
+	fmt.Fprintln(r.opts.Log, "Indexing", p)
+	m, err := NewModule(p, r.opts.Log)
+	if err != nil {
+		return nil, err
+	}
+	r.name = getPackageNameFromModPath(m.ModFile.Module.Mod.Path)
+	if r.config, err = loadConfig(p); err != nil {
+		return nil, err
+	}
+	m.config = r.config
 	err = r.AddModule(m)
 	return r, err
 }
@@ -65,23 +121,10 @@ func (r *Review) Review() (CodeFile, error) {
 
 	lines := []ReviewLine{}
 	nav := []NavigationItem{}
-	diagnostics := []CodeDiagnostic{}
-	packageNames := []string{}
-	for name, p := range r.reviewed.Packages {
-		// we use a prefixed path separator so that we can handle the "internal" module.
-		//  internal/dig
-		//  internal/errorinfo
-		//  etc.
-		// for other modules, we skip /internal subdirectories
-		//  azcore/internal/...
-		if strings.Contains(p.relName, "/internal") || p.c.isEmpty() {
-			continue
-		}
-		packageNames = append(packageNames, name)
-	}
-	sort.Strings(packageNames)
-	for i, name := range packageNames {
-		p := r.reviewed.Packages[name]
+	// rules must run before generating review lines because that removes content such as constructors
+	diagnostics := runRules(r.reviewed, r.config)
+	pkgs := r.reviewed.reviewedPackages()
+	for i, p := range pkgs {
 		n := p.relName
 		line := ReviewLine{
 			Children: []ReviewLine{},
@@ -99,6 +142,13 @@ func (r *Review) Review() (CodeFile, error) {
 				},
 			},
 		}
+		p.c.opts = r.opts
+		p.c.errorTypes = map[string]bool{}
+		for name := range p.c.findErrorTypes() {
+			p.c.errorTypes[name] = true
+		}
+		// generating review lines removes content dependencies() needs, so call it first
+		deps := makeDependencyLines(n, r.reviewed.dependencies(p))
 		// TODO: reordering these calls reorders APIView output and can omit content
 		line.Children = append(line.Children, p.c.parseInterface()...)
 		line.Children = append(line.Children, p.c.parseStructs()...)
@@ -106,6 +156,9 @@ func (r *Review) Review() (CodeFile, error) {
 		line.Children = append(line.Children, p.c.parseVar()...)
 		line.Children = append(line.Children, p.c.parseConst()...)
 		line.Children = append(line.Children, p.c.parseFunc()...)
+		if deps != nil {
+			line.Children = append(line.Children, *deps)
+		}
 		navItems := p.c.generateNavChildItems()
 		nav = append(nav, NavigationItem{
 			Text:         n,
@@ -116,22 +169,12 @@ func (r *Review) Review() (CodeFile, error) {
 			},
 		})
 		diagnostics = append(diagnostics, p.diagnostics...)
-		slices.SortFunc(diagnostics, func(a CodeDiagnostic, b CodeDiagnostic) int {
-			targetCmp := strings.Compare(a.TargetID, b.TargetID)
-			if targetCmp != 0 {
-				return targetCmp
-			}
-			// if the target IDs are the same then fall back to the text.
-			// this accounts for cases where there are multiple diagnostics
-			// for the same target ID.
-			return strings.Compare(a.Text, b.Text)
-		})
 		for _, n := range nav {
 			recursiveSortNavigation(n)
 		}
 		lines = append(lines, line)
 		var tks []ReviewToken
-		if i < len(packageNames)-1 {
+		if i < len(pkgs)-1 {
 			tks = append(tks, ReviewToken{
 				Kind:     TokenKindText,
 				SkipDiff: true,
@@ -141,6 +184,24 @@ func (r *Review) Review() (CodeFile, error) {
 		lines = append(lines, ReviewLine{IsContextEndLine: true, Tokens: tks})
 	}
 
+	directives := []directive{}
+	for _, p := range pkgs {
+		directives = append(directives, p.directives...)
+	}
+	diagnostics = applyDirectives(directives, lines, diagnostics)
+	diagnostics = r.config.apply(diagnostics, r.opts.Log)
+	diagnostics = applyBaseline(r.opts.Baseline, r.opts.BaselineMode, diagnostics)
+	slices.SortFunc(diagnostics, func(a CodeDiagnostic, b CodeDiagnostic) int {
+		targetCmp := strings.Compare(a.TargetID, b.TargetID)
+		if targetCmp != 0 {
+			return targetCmp
+		}
+		// if the target IDs are the same then fall back to the text.
+		// this accounts for cases where there are multiple diagnostics
+		// for the same target ID.
+		return strings.Compare(a.Text, b.Text)
+	})
+
 	// Any ReviewToken having a nonempty NavigateToID that doesn't match some ReviewLine's
 	// LineID will be clickable in API View but won't navigate to anything when clicked. It
 	// would be best simply not to assign such values, but parseAndMakeTypeTokens() does so
@@ -170,12 +231,30 @@ func (r *Review) Review() (CodeFile, error) {
 	}, nil
 }
 
+// position returns the source position of the declaration whose LineID is id. When the review
+// has no position for id, as for fields of inline structs, it returns the position of the
+// nearest enclosing declaration e.g. the struct for "azblob.Options-Retry-Count" and the
+// package for "azblob-dependencies".
+func (r *Review) position(id string) (token.Position, bool) {
+	pkgs := r.reviewed.reviewedPackages()
+	for id != "" {
+		for _, p := range pkgs {
+			if pos, ok := p.positions[id]; ok {
+				return pos, true
+			}
+		}
+		id = id[:max(strings.LastIndexAny(id, "-."), 0)]
+	}
+	return token.Position{}, false
+}
+
 // findLocalModule tries to find the source module defining a type in the same repository as
 // the reviewed module. Returns errExternalModule if the source module is in a different repository.
 func (r *Review) findLocalModule(ta TypeAlias) (*Module, error) {
 	// localModulePath could be inlined but is instead separate for easier testing
 	if dir := localModulePath(ta.SourceMod, r.path); dir != "" {
-		return NewModule(dir)
+		fmt.Fprintln(r.opts.Log, "Indexing", dir)
+		return NewModule(dir, r.opts.Log)
 	}
 	return nil, errExternalModule
 }
@@ -220,7 +299,8 @@ func (r *Review) resolveAliases() error {
 		if m, ok = r.modules[ta.SourceMod.Path]; !ok {
 			m, err = r.findLocalModule(*ta)
 			if errors.Is(err, errExternalModule) {
-				m, err = GetExternalModule(ta.SourceMod)
+				fmt.Fprintln(r.opts.Log, "Indexing", ta.SourceMod)
+				m, err = GetExternalModule(ta.SourceMod, r.opts.Log)
 			}
 			if err == nil {
 				err = r.AddModule(m)
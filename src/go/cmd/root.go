@@ -16,18 +16,151 @@ var rootCmd = &cobra.Command{
 	Long: `apiviewgo outputs a file representing the public API of an Azure SDK for Go
 module in APIView format. It writes this file to <outputDir>/<module name>.json,
 overwriting any file of the same name.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	// the root command takes positional args despite having subcommands
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 2 {
-			err := cmd.Help()
-			if err != nil {
-				fmt.Println(err)
+			return cmd.Help()
+		}
+		// errors after this point aren't usage errors
+		cmd.SilenceUsage = true
+		o := ReviewOptions{Layout: Layout(layout), StructTags: StructTagMode(structTags)}
+		switch o.Layout {
+		case LayoutAlphabetical, LayoutSource:
+		default:
+			return fmt.Errorf("invalid --layout value %q", layout)
+		}
+		switch o.StructTags {
+		case StructTagsHidden, StructTagsSkipDiff, StructTagsShown:
+		default:
+			return fmt.Errorf("invalid --struct-tags value %q", structTags)
+		}
+		if err := setBaseline(&o); err != nil {
+			return err
+		}
+		// review the module once, writing every output from the same review
+		r, err := NewReview(args[0], &o)
+		if err != nil {
+			return err
+		}
+		review, err := r.Review()
+		if err != nil {
+			return err
+		}
+		if err := writeAPIView(review, args[1]); err != nil {
+			return err
+		}
+		if locations {
+			if err := r.writeLocations(review, args[1]); err != nil {
+				return err
 			}
-			return
 		}
-		err := CreateAPIView(args[0], args[1])
+		if sarif != "" {
+			return r.writeSARIF(review, sarif)
+		}
+		return nil
+	},
+	// Execute prints errors
+	SilenceErrors: true,
+}
+
+// setBaseline sets the baseline options of o from the values of the --baseline and --baseline-mode flags
+func setBaseline(o *ReviewOptions) error {
+	switch BaselineMode(baselineMode) {
+	case BaselineDowngrade, BaselineSuppress:
+		o.BaselineMode = BaselineMode(baselineMode)
+	default:
+		return fmt.Errorf("invalid --baseline-mode value %q", baselineMode)
+	}
+	if baseline != "" {
+		b, err := loadBaseline(baseline)
+		if err != nil {
+			return err
+		}
+		o.Baseline = b
+	}
+	return nil
+}
+
+var (
+	// baseline is the value of the --baseline flag
+	baseline string
+	// baselineMode is the value of the --baseline-mode flag
+	baselineMode string
+	// failLevel is the value of the check command's --level flag
+	failLevel string
+	// layout is the value of the --layout flag
+	layout string
+	// locations is the value of the --locations flag
+	locations bool
+	// sarif is the value of the --sarif flag
+	sarif string
+	// structTags is the value of the --struct-tags flag
+	structTags string
+)
+
+func init() {
+	rootCmd.AddCommand(checkCmd, locateCmd)
+	for _, c := range []*cobra.Command{rootCmd, checkCmd} {
+		c.Flags().StringVar(&baseline, "baseline", "",
+			"path of a previous review whose diagnostics to suppress or downgrade in this one")
+		c.Flags().StringVar(&baselineMode, "baseline-mode", string(BaselineSuppress),
+			fmt.Sprintf("what to do with diagnostics the baseline has: %q or %q", BaselineSuppress, BaselineDowngrade))
+	}
+	checkCmd.Flags().StringVar(&failLevel, "level", CodeDiagnosticLevelFatal.String(),
+		fmt.Sprintf("lowest level of diagnostic that fails the check: %q, %q, %q or %q",
+			CodeDiagnosticLevelInfo, CodeDiagnosticLevelWarning, CodeDiagnosticLevelError, CodeDiagnosticLevelFatal))
+	rootCmd.Flags().StringVar(&layout, "layout", string(LayoutAlphabetical),
+		fmt.Sprintf("order of fields, consts, vars and funcs: %q or %q", LayoutAlphabetical, LayoutSource))
+	rootCmd.Flags().BoolVar(&locations, "locations", false,
+		"also write <outputDir>/<module name>"+locationsFileSuffix+", mapping each line of the review to its source location")
+	rootCmd.Flags().StringVar(&sarif, "sarif", "",
+		"path of a file to which to write the review's diagnostics in SARIF format")
+	rootCmd.Flags().StringVar(&structTags, "struct-tags", string(StructTagsHidden),
+		fmt.Sprintf("how to display struct field tags: %q, %q or %q", StructTagsHidden, StructTagsSkipDiff, StructTagsShown))
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check <moduleDir>",
+	Short: "Fail when a module's review has diagnostics at or above a level",
+	Long: `check reviews the module in moduleDir and prints a summary of the review's diagnostics.
+It exits with a nonzero status when any diagnostic is at or above the level given by --level,
+or when the review fails, so pipelines can block changes violating API guidelines.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// errors after this point aren't usage errors
+		cmd.SilenceUsage = true
+		level, err := parseLevel(failLevel)
+		if err != nil {
+			return fmt.Errorf("invalid --level value %q", failLevel)
+		}
+		o := ReviewOptions{}
+		if err := setBaseline(&o); err != nil {
+			return err
+		}
+		return Check(args[0], level, &o, os.Stdout)
+	},
+}
+
+var locateCmd = &cobra.Command{
+	Use:   "locate <LineID> [moduleDir]",
+	Short: "Print the source location of a review line",
+	Long: `locate prints the file, line and column in source of the declaration having the given
+LineID in a review of the module in moduleDir, which defaults to the working directory.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// errors after this point aren't usage errors
+		cmd.SilenceUsage = true
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+		loc, err := Locate(dir, args[0])
 		if err != nil {
-			fmt.Println(err)
+			return err
 		}
+		fmt.Println(loc.in(dir))
+		return nil
 	},
 }
 
@@ -0,0 +1,284 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Rule checks the API of an indexed module, returning a diagnostic for each problem it finds.
+// Rules run after the module's type aliases are resolved and before its review lines are
+// generated, so they see the complete content of each package. Rules must not modify the
+// module. Add a rule to reviews by registering it with [RegisterRule].
+type Rule interface {
+	// ID returns the rule's stable identifier e.g. "GO001". It becomes the DiagnosticID of
+	// the rule's diagnostics.
+	ID() string
+
+	// HelpLinkURI returns a link to the guideline the rule enforces. It becomes the HelpLinkURI
+	// of the rule's diagnostics. It may be empty.
+	HelpLinkURI() string
+
+	// Check returns diagnostics for the given module. The returned diagnostics needn't have
+	// a DiagnosticID or HelpLinkURI because the caller sets those from the Rule.
+	Check(m *Module) []CodeDiagnostic
+}
+
+// Help links for diagnostics. Each links to the guideline a diagnostic enforces. Diagnostics
+// enforcing no published guideline have no link rather than one to a general page.
+const (
+	helpClientConstructors = "https://azure.github.io/azure-sdk/golang_introduction.html#go-client-constructors"
+	helpClientMethods      = "https://azure.github.io/azure-sdk/golang_introduction.html#go-client-methods"
+	helpCodeReviewComments = "https://go.dev/wiki/CodeReviewComments"
+	helpDependencies       = "https://azure.github.io/azure-sdk/golang_implementation.html#go-dependencies"
+	helpDocComments        = "https://go.dev/doc/comment"
+	helpEffectiveGo        = "https://go.dev/doc/effective_go"
+	helpEmbedding          = "https://go.dev/doc/effective_go#embedding"
+	helpEnums              = "https://azure.github.io/azure-sdk/golang_introduction.html#go-enums"
+	helpErrors             = "https://azure.github.io/azure-sdk/golang_introduction.html#go-errors"
+	helpErrorWrapping      = "https://go.dev/blog/go1.13-errors"
+	helpGoDirective        = "https://go.dev/ref/mod#go-mod-file-go"
+	helpInternalPackages   = "https://pkg.go.dev/cmd/go#hdr-Internal_Directories"
+	helpModels             = "https://azure.github.io/azure-sdk/golang_introduction.html#go-models"
+	helpSyncPackage        = "https://pkg.go.dev/sync"
+	helpReadme             = "https://github.com/Azure/azure-sdk-tools/blob/main/src/go/README.md"
+)
+
+// IDs of diagnostics that don't come from rules. They're numbered apart from rule IDs so
+// adding rules never changes them.
+const (
+	aliasDiagnosticID            = "GO900"
+	noteDiagnosticID             = "GO901"
+	unknownDirectiveDiagnosticID = "GO902"
+)
+
+// rules is the registry of Rules applied to every review. Its keys are rule IDs.
+var rules = map[string]Rule{}
+
+// RegisterRule adds a rule to the registry. It panics when the registry already has a rule
+// with the same ID because diagnostic IDs must be unique.
+func RegisterRule(r Rule) {
+	if _, ok := rules[r.ID()]; ok {
+		panic(fmt.Sprintf("rule %s is already registered", r.ID()))
+	}
+	rules[r.ID()] = r
+}
+
+// NewRule returns a Rule that calls check. It's a convenient way to implement simple rules.
+func NewRule(id, helpLinkURI string, check func(*Module) []CodeDiagnostic) Rule {
+	return funcRule{check: check, help: helpLinkURI, id: id}
+}
+
+type funcRule struct {
+	check    func(*Module) []CodeDiagnostic
+	help, id string
+}
+
+func (r funcRule) Check(m *Module) []CodeDiagnostic {
+	return r.check(m)
+}
+
+func (r funcRule) HelpLinkURI() string {
+	return r.help
+}
+
+func (r funcRule) ID() string {
+	return r.id
+}
+
+// runRules applies the registered rules cfg enables to m, returning their diagnostics
+func runRules(m *Module, cfg Config) []CodeDiagnostic {
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		if cfg.enabled(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	diagnostics := []CodeDiagnostic{}
+	for _, id := range ids {
+		r := rules[id]
+		for _, d := range r.Check(m) {
+			d.DiagnosticID = r.ID()
+			d.HelpLinkURI = r.HelpLinkURI()
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// diagnostic messages
+const (
+	embedsUnexportedStruct = "Anonymously embeds unexported struct "
+	inconsistentTagName    = "json tag name doesn't follow the naming convention of sibling fields: "
+	missingOmitempty       = "Pointer field's json tag lacks omitempty, unlike its siblings"
+	sealedInterface        = "Applications can't implement this interface"
+)
+
+func init() {
+	RegisterRule(NewRule("GO001", "", checkSealedInterfaces))
+	RegisterRule(NewRule("GO002", helpEmbedding, checkEmbeddedStructs))
+	RegisterRule(NewRule("GO003", "", checkStructTags))
+}
+
+// reviewedAlias returns whether the named type of package p is an alias for a type defined in
+// another of the module's reviewed packages. Rules about a type's definition skip such aliases
+// so that the review has one diagnostic for the definition rather than one for each alias.
+func (m *Module) reviewedAlias(p *Pkg, name string) bool {
+	for _, a := range p.TypeAliases {
+		if a.Name != name {
+			continue
+		}
+		dot := strings.LastIndex(a.QualifiedName, ".")
+		if dot < 0 {
+			return false
+		}
+		src, ok := m.Packages[a.QualifiedName[:dot]]
+		return ok && slices.Contains(m.reviewedPackages(), src)
+	}
+	return false
+}
+
+// checkSealedInterfaces notes exported interfaces having unexported methods
+func checkSealedInterfaces(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, in := range p.c.Interfaces {
+			if in.Exported() && in.Sealed && !m.reviewedAlias(p, in.Name()) {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelInfo,
+					TargetID: in.ID(),
+					Text:     sealedInterface,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkEmbeddedStructs flags exported structs anonymously embedding unexported types
+func checkEmbeddedStructs(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, s := range p.c.Structs {
+			if !s.Exported() || m.reviewedAlias(p, s.Name()) {
+				continue
+			}
+			for _, t := range s.AnonymousFields {
+				// if t contains "." it must be exported
+				if !strings.Contains(t, ".") && unicode.IsLower(rune(t[0])) {
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelError,
+						TargetID: s.ID(),
+						Text:     embedsUnexportedStruct + t,
+					})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkStructTags flags struct fields whose tags disagree with those of their siblings
+func checkStructTags(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	for _, p := range m.reviewedPackages() {
+		for _, s := range p.c.Structs {
+			if s.Exported() && !m.reviewedAlias(p, s.Name()) {
+				diagnostics = append(diagnostics, structTagDiagnostics(s)...)
+			}
+		}
+	}
+	return diagnostics
+}
+
+// structTagDiagnostics returns diagnostics for exported fields whose json tags disagree with
+// the conventions of their siblings, for example a pointer field lacking "omitempty" when other
+// pointer fields have it, or a "snake_case" name among "camelCase" names
+func structTagDiagnostics(s Struct) []CodeDiagnostic {
+	type jsonTag struct {
+		name, style string
+		omitempty   bool
+	}
+	tags := map[string]jsonTag{}
+	fields := []string{}
+	pointersOmitEmpty := 0
+	styles := map[string]int{}
+	for field, tag := range s.tags {
+		if !token.IsExported(field) {
+			continue
+		}
+		v, ok := reflect.StructTag(strings.Trim(tag, "`")).Lookup("json")
+		if !ok {
+			continue
+		}
+		name, opts, _ := strings.Cut(v, ",")
+		if name == "-" {
+			continue
+		}
+		jt := jsonTag{name: name, style: jsonNameStyle(name), omitempty: slices.Contains(strings.Split(opts, ","), "omitempty")}
+		if jt.omitempty && strings.HasPrefix(s.fields[field], "*") {
+			pointersOmitEmpty++
+		}
+		if jt.style != "" {
+			styles[jt.style]++
+		}
+		tags[field] = jt
+		fields = append(fields, field)
+	}
+	// the convention is the style of more than half the tags having a distinguishable style, if any
+	styled := 0
+	for _, n := range styles {
+		styled += n
+	}
+	convention := ""
+	for style, n := range styles {
+		if n > 1 && n*2 > styled {
+			convention = style
+		}
+	}
+	sort.Strings(fields)
+	diagnostics := []CodeDiagnostic{}
+	for _, field := range fields {
+		jt := tags[field]
+		id := s.ID() + "-" + field
+		if !jt.omitempty && pointersOmitEmpty > 0 && strings.HasPrefix(s.fields[field], "*") {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: id,
+				Text:     missingOmitempty,
+			})
+		}
+		if convention != "" && jt.style != "" && jt.style != convention {
+			diagnostics = append(diagnostics, CodeDiagnostic{
+				Level:    CodeDiagnosticLevelWarning,
+				TargetID: id,
+				Text:     inconsistentTagName + jt.name,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// jsonNameStyle returns the naming convention of a json property name: "snake_case", "PascalCase"
+// or "camelCase". It returns an empty string for names such as "id" that fit more than one style.
+func jsonNameStyle(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case strings.Contains(name, "_"):
+		return "snake_case"
+	case unicode.IsUpper(rune(name[0])):
+		return "PascalCase"
+	case strings.IndexFunc(name, unicode.IsUpper) > 0:
+		return "camelCase"
+	}
+	return ""
+}
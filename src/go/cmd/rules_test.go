@@ -0,0 +1,546 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRule(t *testing.T) {
+	const id, link = "TEST001", "https://example.com/guidelines"
+	RegisterRule(NewRule(id, link, func(m *Module) []CodeDiagnostic {
+		ds := []CodeDiagnostic{}
+		for _, p := range m.reviewedPackages() {
+			for _, s := range p.c.Structs {
+				if s.Exported() {
+					ds = append(ds, CodeDiagnostic{Level: CodeDiagnosticLevelWarning, TargetID: s.ID(), Text: "test rule"})
+				}
+			}
+		}
+		return ds
+	}))
+	defer delete(rules, id)
+	require.Panics(t, func() { RegisterRule(NewRule(id, "", nil)) }, "duplicate rule IDs should panic")
+
+	review, err := createReview(filepath.Clean("testdata/test_diagnostics"), nil)
+	require.NoError(t, err)
+	targets := []string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == id {
+			require.Equal(t, link, d.HelpLinkURI)
+			targets = append(targets, d.TargetID)
+		}
+	}
+	// Alias is a struct because the review includes the definition of an aliased type
+	require.Equal(t, []string{"test_diagnostics.Alias", "test_diagnostics.ExportedStruct"}, targets)
+}
+
+func TestConfig(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_config"), nil)
+	require.NoError(t, err)
+	// GO001 is disabled, GO002 is downgraded to a warning and suppressed for SuppressedEmbedder
+	require.Equal(t, 1, len(review.Diagnostics))
+	require.Equal(t, "GO002", review.Diagnostics[0].DiagnosticID)
+	require.Equal(t, CodeDiagnosticLevelWarning, review.Diagnostics[0].Level)
+	require.Equal(t, "test_config.Embedder", review.Diagnostics[0].TargetID)
+}
+
+func TestConfigValidation(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "empty allowed dependency",
+			cfg:  Config{AllowedDependencies: []string{""}},
+		},
+		{
+			name: "unknown rule",
+			cfg:  Config{Rules: map[string]RuleConfig{"GO999": {}}},
+		},
+		{
+			name: "invalid level",
+			cfg:  Config{Rules: map[string]RuleConfig{"GO001": {Level: "severe"}}},
+		},
+		{
+			name: "missing justification",
+			cfg:  Config{Suppressions: []Suppression{{Target: "test_config.Embedder"}}},
+		},
+		{
+			name: "missing target",
+			cfg:  Config{Suppressions: []Suppression{{Justification: "because"}}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Error(t, test.cfg.validate())
+		})
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_directives"), nil)
+	require.NoError(t, err)
+
+	hidden := []string{}
+	forAll(review.ReviewLines, func(rl ReviewLine) {
+		if rl.IsHidden && rl.LineID != "" {
+			hidden = append(hidden, rl.LineID)
+		}
+	})
+	require.ElementsMatch(t, []string{"test_directives.Hidden", "test_directives.Hidden-Field", "test_directives.HiddenNoted", "test_directives.HiddenNoted-Bad_Name", "test_directives.Visible-Secret"}, hidden)
+
+	diagnostics := map[string]CodeDiagnostic{}
+	for _, d := range review.Diagnostics {
+		diagnostics[d.TargetID] = d
+	}
+	// GO002 is suppressed for Embedder. HiddenNoted's note and the diagnostic for its
+	// field Bad_Name are dropped because the review hides their targets.
+	require.Equal(t, 3, len(review.Diagnostics))
+	require.Equal(t, CodeDiagnosticLevelInfo, diagnostics["test_directives.Visible-Noted"].Level)
+	require.Equal(t, "Noted counts things", diagnostics["test_directives.Visible-Noted"].Text)
+	require.Equal(t, CodeDiagnosticLevelInfo, diagnostics["test_directives-NewVisible"].Level)
+	require.Equal(t, "prefer a composite literal", diagnostics["test_directives-NewVisible"].Text)
+	require.Equal(t, CodeDiagnosticLevelWarning, diagnostics["test_directives.Constant"].Level)
+	require.Contains(t, diagnostics["test_directives.Constant"].Text, unknownDirective)
+	require.Equal(t, unknownDirectiveDiagnosticID, diagnostics["test_directives.Constant"].DiagnosticID)
+	require.Equal(t, noteDiagnosticID, diagnostics["test_directives-NewVisible"].DiagnosticID)
+}
+
+func TestDiagnosticIDs(t *testing.T) {
+	// the README lists the rules having no published guideline
+	unlinked := []string{"GO001", "GO003", "GO021"}
+	for id, r := range rules {
+		require.Regexp(t, `^GO[0-8]\d\d$`, id)
+		require.Equal(t, slices.Contains(unlinked, id), r.HelpLinkURI() == "", "rule %s", id)
+		if link := r.HelpLinkURI(); link != "" {
+			require.True(t, strings.HasPrefix(link, "https://"), "rule %s has an invalid help link", id)
+			require.NotRegexp(t, `azure\.github\.io/.*\.html$`, link, "rule %s should link to a specific guideline", id)
+		}
+	}
+	for _, dir := range []string{"test_alias_diagnostics", "test_directives", "test_errors", "test_naming", "test_output"} {
+		t.Run(dir, func(t *testing.T) {
+			review, err := createReview(filepath.Join("testdata", dir), nil)
+			require.NoError(t, err)
+			require.NotEmpty(t, review.Diagnostics)
+			for _, d := range review.Diagnostics {
+				require.Regexp(t, `^GO\d{3}$`, d.DiagnosticID, "%s: %s", d.TargetID, d.Text)
+				link := ""
+				if r, ok := rules[d.DiagnosticID]; ok {
+					link = r.HelpLinkURI()
+				} else if d.DiagnosticID == unknownDirectiveDiagnosticID {
+					link = helpReadme
+				}
+				require.Equal(t, link, d.HelpLinkURI, "%s: %s", d.TargetID, d.Text)
+			}
+		})
+	}
+}
+
+func TestClientMethodRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_clients"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO004", "GO005", "GO006":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_clients-(c *SubClient) Delete": {
+			clientMethodContext,
+			clientMethodOptions + "options *SubClientDeleteOptions",
+			clientMethodReturns + "(SubClientDeleteResponse, error)",
+		},
+		"test_clients-(c *SubClient) BeginUpdate": {
+			clientMethodReturns + "(*runtime.Poller[SubClientUpdateResponse], error)",
+		},
+		"test_clients-(c *SubClient) BeginPurge": {
+			clientMethodReturns + "(*runtime.Poller[SubClientPurgeResponse], error)",
+		},
+		"test_clients-(c *SubClient) Get": {
+			clientMethodReturns + "(SubClientGetResponse, error)",
+		},
+		"test_clients-(c *SubClient) NewListPager": {
+			clientMethodOptions + "options *SubClientListOptions",
+			clientMethodReturns + "*runtime.Pager[SubClientListResponse]",
+		},
+		// test_clients.AliasedClient is an alias for this type, which rules check only here
+		"test_clients/sub-(c *AliasedClient) Delete": {
+			clientMethodContext,
+			clientMethodOptions + "options *AliasedClientDeleteOptions",
+			clientMethodReturns + "(AliasedClientDeleteResponse, error)",
+		},
+	}, actual)
+}
+
+func TestClientConstructionRule(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_clients"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO007" {
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_clients.NoOptionsClient": {
+			clientCtorOptions + "NewNoOptionsClient",
+			clientNoOptions + "NoOptionsClientOptions",
+		},
+		"test_clients.OrphanClient":       {clientNoCtor},
+		"test_clients.PlainOptionsClient": {clientOptionsEmbed + "PlainOptionsClientOptions"},
+	}, actual)
+}
+
+func TestSplitWords(t *testing.T) {
+	for name, expected := range map[string][]string{
+		"Client":        {"Client"},
+		"HTTPClient":    {"HTTP", "Client"},
+		"HTTPClientIDs": {"HTTP", "Client", "IDs"},
+		"UTF8Encoding":  {"UTF8", "Encoding"},
+		"getUrl":        {"get", "Url"},
+		"Enum2_1":       {"Enum2", "1"},
+	} {
+		require.Equal(t, expected, splitWords(name), name)
+	}
+}
+
+func TestNamingRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_naming"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO008", "GO009", "GO010", "GO011":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_naming.Item-ItemIds":  {namingInitialism + "Ids should be IDs"},
+		"test_naming.Item-Raw_Data": {namingUnderscore},
+		"test_naming-(c *Test_namingClient) GetHttpStatus": {
+			namingInitialism + "Http should be HTTP",
+		},
+		"test_naming-NewJsonItem": {namingInitialism + "Json should be JSON"},
+		"test_naming.PageBlob":    {namingEnumPrefix + "BlobType"},
+		"test_naming.Test_namingClient": {
+			namingUnderscore,
+			namingStutter + "test_naming",
+		},
+		"test_naming/widgetapi.WidgetsOptions": {namingStutter + "widgets"},
+	}, actual)
+}
+
+func TestModelRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_models"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO012", "GO013", "GO014":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_models.Gadget-Count":   {modelFieldTypes + "*int32 (test_models), *int64 (test_models)"},
+		"test_models.Gadget-Kind":    {modelPointer},
+		"test_models.Gadget-Name":    {modelFieldTypes + "*string (test_models), string (test_models)"},
+		"test_models.Widget-Count":   {modelFieldTypes + "*int32 (test_models), *int64 (test_models)"},
+		"test_models.Widget-Created": {modelTimeFormat},
+		"test_models.Widget-Name": {
+			modelPointer,
+			modelFieldTypes + "*string (test_models), string (test_models)",
+		},
+		// the type of Tags differs between packages
+		"test_models.Widget-Tags":         {modelFieldTypes + "[]*string (test_models), []string (test_models/timed)"},
+		"test_models/timed.Deadline-Due":  {modelTimeFormat},
+		"test_models/timed.Deadline-Tags": {modelFieldTypes + "[]*string (test_models), []string (test_models/timed)"},
+	}, actual)
+}
+
+func TestEnumRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_enums"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO015", "GO016", "GO017":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_enums.Color":              {enumNoPossibleVals + "PossibleColorValues"},
+		"test_enums.KindB":              {enumForeignConst + "test_enums/sub"},
+		"test_enums-PossibleSizeValues": {enumNoConsts},
+	}, actual)
+}
+
+func TestTypeReferenceRule(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_type_refs"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO018" {
+			require.Equal(t, CodeDiagnosticLevelError, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_type_refs.Holder":         {missingAliasFor + "Base"},
+		"test_type_refs.Holder-Handler": {unexportedType + "value"},
+		"test_type_refs-NewThing":       {unexportedType + "thing"},
+		"test_type_refs-Use":            {missingAliasFor + "Options"},
+	}, actual)
+}
+
+func TestDependencies(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_dependencies"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO019" {
+			require.Equal(t, CodeDiagnosticLevelError, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"azwidgets-(c *Client) Get": {disallowedDependency + "github.com/google/uuid"},
+		"azwidgets.Widget-ID":       {disallowedDependency + "github.com/google/uuid"},
+	}, actual)
+
+	// applications can't import another module's internal package, so nothing may refer to its types
+	refs := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO018" {
+			refs[d.TargetID] = append(refs[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"azwidgets.Widget-Shared": {unimportableType + "shared.Value (github.com/Azure/azure-sdk-for-go/sdk/widgets/internal/shared)"},
+	}, refs)
+
+	deps := []string{}
+	searchLines(review.ReviewLines, func(rl ReviewLine) bool {
+		if rl.LineID == "azwidgets-dependencies" {
+			for _, c := range rl.Children {
+				txt := []string{}
+				for _, tk := range c.Tokens {
+					txt = append(txt, tk.Value)
+				}
+				deps = append(deps, strings.Join(txt, " "))
+			}
+			return true
+		}
+		return false
+	})
+	require.Equal(t, []string{
+		"github.com/Azure/azure-sdk-for-go/sdk/azcore v1.11.0 (1 reference)",
+		"github.com/Azure/azure-sdk-for-go/sdk/widgets/internal v0.1.0 (1 reference)",
+		// Client.Get and Widget.ID refer to uuid
+		"github.com/google/uuid v1.6.0 (2 references)",
+		"github.com/other/allowed v1.0.0 (1 reference)",
+	}, deps)
+}
+
+func TestStdlibVersionRule(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_stdlib_version"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO020" {
+			require.Equal(t, CodeDiagnosticLevelError, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_stdlib_version.Layout":        {stdlibVersion + "time.DateOnly requires go 1.20"},
+		"test_stdlib_version.Options-Level": {stdlibVersion + "log/slog.Level requires go 1.21"},
+		"test_stdlib_version-Levels": {
+			stdlibVersion + "iter.Seq requires go 1.23",
+			stdlibVersion + "log/slog.Level requires go 1.21",
+		},
+		"test_stdlib_version-Pairs": {
+			stdlibVersion + "iter.Seq2 requires go 1.23",
+			stdlibVersion + "log/slog.Level requires go 1.21",
+		},
+	}, actual)
+}
+
+func TestMutabilityRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_mutability"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO021", "GO022":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		// Cache holds its mutex in an unexported field
+		"test_mutability.Cache":          {syncValueHeld + "mu sync.Mutex"},
+		"test_mutability-CopyCache":      {syncValueReturned + "Cache holds a sync.Mutex"},
+		"test_mutability.DefaultClient":  {mutableVar + "pointer"},
+		"test_mutability.DefaultHeaders": {mutableVar + "map"},
+		"test_mutability.DefaultOptions": {mutableVar + "pointer"},
+		"test_mutability.Group":          {syncValueExposed + "sync.WaitGroup"},
+		"test_mutability.Regions":        {mutableVar + "slice"},
+		"test_mutability.Tracker-Lock":   {syncValueExposed + "sync.RWMutex"},
+	}, actual)
+}
+
+func TestErrorRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_errors"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO023", "GO024", "GO025", "GO026":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_errors-(f Failure) Error": {errorPointer},
+		"test_errors.Code":              {errorName},
+		"test_errors.Failure":           {errorName},
+		"test_errors.NotFound":          {errorSentinelPrefix},
+		"test_errors.Timeout":           {errorSentinelPrefix},
+		"test_errors.WrapError":         {errorMissingUnwrap},
+	}, actual)
+
+	kinds := map[string]string{}
+	for _, item := range review.Navigation[0].ChildItems {
+		kinds[item.Text] = (*item.Tags)["TypeKind"]
+	}
+	for _, name := range []string{"CauseError", "Code", "Failure", "ResponseError", "WrapError"} {
+		require.Equal(t, "error", kinds[name], name)
+	}
+	require.Equal(t, "unknown", kinds["ErrClosed"])
+}
+
+func TestSpellingRule(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_spelling"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		if d.DiagnosticID == "GO027" {
+			require.Equal(t, CodeDiagnosticLevelInfo, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_spelling-RecieveMessage": {
+			misspelledWord + "recieve (receive)",
+			misspelledWord + "retreive (retrieve)",
+		},
+		"test_spelling.Message": {misspelledWord + "recieve (receive)"},
+		"test_spelling.Message-Body": {
+			misspelledWord + "recieve (receive)",
+			misspelledWord + "succesful (successful)",
+		},
+		// misspellings having no suggested correction
+		"test_spelling.Reciept":               {misspelledWord + "reciept", misspelledWord + "subscripton"},
+		"test_spelling.Reciept-SubscriptonID": {misspelledWord + "subscripton"},
+	}, actual)
+}
+
+func TestParamRules(t *testing.T) {
+	review, err := createReview(filepath.Clean("testdata/test_params"), nil)
+	require.NoError(t, err)
+	actual := map[string][]string{}
+	for _, d := range review.Diagnostics {
+		switch d.DiagnosticID {
+		case "GO028", "GO029":
+			require.Equal(t, CodeDiagnosticLevelWarning, d.Level)
+			actual[d.TargetID] = append(actual[d.TargetID], d.Text)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"test_params-(c *Client) Do":    {paramNameInconsistent + "r *Request should be req *Request"},
+		"test_params-(c *Client) Sleep": {paramTypeInconsistent + "timeout int should be timeout time.Duration"},
+		"test_params-(c *Client) Wait":  {paramNameInconsistent + "cx context.Context should be ctx context.Context"},
+		"test_params.Sender-Send":       {paramNameInconsistent + "c context.Context should be ctx context.Context"},
+		// names of predeclared types are compared only with names they abbreviate or that abbreviate
+		// them, so "name string" and "key string" aren't flagged
+		"test_params-GetGroup": {paramNameInconsistent + "rgName string should be resourceGroupName string"},
+	}, actual)
+}
+
+func TestAbbreviates(t *testing.T) {
+	for _, c := range []struct {
+		short, long string
+		expected    bool
+	}{
+		{"ctx", "context", true},
+		{"req", "request", true},
+		{"rgName", "resourceGroupName", true},
+		{"rgname", "resourceGroupName", true},
+		{"resourceGroupName", "rgName", false},
+		{"name", "resourceGroupName", false},
+		{"groupName", "resourceGroupName", false},
+		{"key", "name", false},
+		{"ctx", "ctx", false},
+	} {
+		require.Equal(t, c.expected, abbreviates(c.short, c.long), "%s %s", c.short, c.long)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	previous, err := createReview(filepath.Clean("testdata/test_diagnostics"), nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(previous.Diagnostics))
+	known := previous.Diagnostics[:2]
+	previous.Diagnostics = known
+	b, err := json.Marshal(previous)
+	require.NoError(t, err)
+	p := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(p, b, 0600))
+	baseline, err := loadBaseline(p)
+	require.NoError(t, err)
+
+	isKnown := func(d CodeDiagnostic) bool {
+		for _, k := range known {
+			if k.TargetID == d.TargetID && k.Text == d.Text {
+				return true
+			}
+		}
+		return false
+	}
+	t.Run("suppress", func(t *testing.T) {
+		review, err := createReview(filepath.Clean("testdata/test_diagnostics"), &ReviewOptions{Baseline: baseline})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(review.Diagnostics))
+		for _, d := range review.Diagnostics {
+			require.False(t, isKnown(d))
+		}
+	})
+	t.Run("downgrade", func(t *testing.T) {
+		review, err := createReview(filepath.Clean("testdata/test_diagnostics"), &ReviewOptions{Baseline: baseline, BaselineMode: BaselineDowngrade})
+		require.NoError(t, err)
+		require.Equal(t, 4, len(review.Diagnostics))
+		downgraded := 0
+		for _, d := range review.Diagnostics {
+			if isKnown(d) {
+				require.Equal(t, CodeDiagnosticLevelInfo, d.Level)
+				downgraded++
+			}
+		}
+		require.Equal(t, 2, downgraded)
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// This file contains models and functions for exporting diagnostics in SARIF 2.1 format, which
+// code scanning tools such as GitHub's can display on the lines of a diff
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+	Version string     `json:"version"`
+}
+
+type sarifRun struct {
+	Results []sarifResult `json:"results"`
+	Tool    sarifTool     `json:"tool"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	InformationURI string      `json:"informationUri,omitempty"`
+	Name           string      `json:"name"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	HelpURI string `json:"helpUri,omitempty"`
+	ID      string `json:"id"`
+}
+
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Locations []sarifLocation `json:"locations"`
+	Message   sarifMessage    `json:"message"`
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	// PhysicalLocation is nil when the diagnostic's target has no known source position
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	// FullyQualifiedName is the LineID the diagnostic targets
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartColumn int `json:"startColumn"`
+	StartLine   int `json:"startLine"`
+}
+
+// sarifLevel returns the SARIF level corresponding to a diagnostic level
+func sarifLevel(l CodeDiagnosticLevel) string {
+	switch l {
+	case CodeDiagnosticLevelInfo:
+		return "note"
+	case CodeDiagnosticLevelWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// sarifURI returns the URI of a source file. Code scanning tools resolve relative URIs against
+// the root of the repository, so the URI is relative to baseDir when the file is within it.
+func sarifURI(filename, baseDir string) string {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		abs = filename
+	}
+	if rel, err := filepath.Rel(baseDir, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return "file://" + filepath.ToSlash(abs)
+}
+
+// sarif returns a SARIF log of the review's diagnostics, locating each by the source position of
+// the declaration it targets. File URIs are relative to baseDir.
+func (r *Review) sarif(cf CodeFile, baseDir string) sarifLog {
+	help := map[string]string{}
+	for _, d := range cf.Diagnostics {
+		help[d.DiagnosticID] = d.HelpLinkURI
+	}
+	ids := make([]string, 0, len(help))
+	for id := range help {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	indexes := map[string]int{}
+	driverRules := make([]sarifRule, len(ids))
+	for i, id := range ids {
+		indexes[id] = i
+		driverRules[i] = sarifRule{HelpURI: help[id], ID: id}
+	}
+
+	results := make([]sarifResult, 0, len(cf.Diagnostics))
+	for _, d := range cf.Diagnostics {
+		loc := sarifLocation{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: d.TargetID}}}
+		if pos, ok := r.position(d.TargetID); ok {
+			loc.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(pos.Filename, baseDir)},
+				Region:           sarifRegion{StartColumn: pos.Column, StartLine: pos.Line},
+			}
+		}
+		results = append(results, sarifResult{
+			Level:     sarifLevel(d.Level),
+			Locations: []sarifLocation{loc},
+			Message:   sarifMessage{Text: d.Text},
+			RuleID:    d.DiagnosticID,
+			RuleIndex: indexes[d.DiagnosticID],
+		})
+	}
+	return sarifLog{
+		Schema: sarifSchema,
+		Runs: []sarifRun{
+			{
+				Results: results,
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						InformationURI: helpReadme,
+						Name:           "apiviewgo",
+						Rules:          driverRules,
+					},
+				},
+			},
+		},
+		Version: sarifVersion,
+	}
+}
+
+// writeSARIF writes the diagnostics of cf, the review r generated, to the file at path in SARIF
+// format. Source file URIs are relative to the working directory.
+func (r *Review) writeSARIF(cf CodeFile, path string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r.sarif(cf, wd), "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
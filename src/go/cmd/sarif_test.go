@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSARIF(t *testing.T) {
+	r, err := NewReview(filepath.Clean("testdata/test_directives"), nil)
+	require.NoError(t, err)
+	cf, err := r.Review()
+	require.NoError(t, err)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	log := r.sarif(cf, wd)
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	require.Equal(t, []sarifRule{
+		{ID: noteDiagnosticID},
+		{HelpURI: helpReadme, ID: unknownDirectiveDiagnosticID},
+	}, run.Tool.Driver.Rules)
+
+	type location struct {
+		level, ruleID, uri string
+		line, column       int
+	}
+	actual := map[string]location{}
+	for _, res := range run.Results {
+		require.Len(t, res.Locations, 1)
+		loc := res.Locations[0]
+		require.NotNil(t, loc.PhysicalLocation)
+		require.Equal(t, res.RuleID, run.Tool.Driver.Rules[res.RuleIndex].ID)
+		actual[loc.LogicalLocations[0].FullyQualifiedName] = location{
+			column: loc.PhysicalLocation.Region.StartColumn,
+			level:  res.Level,
+			line:   loc.PhysicalLocation.Region.StartLine,
+			ruleID: res.RuleID,
+			uri:    loc.PhysicalLocation.ArtifactLocation.URI,
+		}
+	}
+	const uri = "testdata/test_directives/test.go"
+	require.Equal(t, map[string]location{
+		"test_directives.Constant":      {column: 2, level: "warning", line: 33, ruleID: unknownDirectiveDiagnosticID, uri: uri},
+		"test_directives.Visible-Noted": {column: 2, level: "note", line: 21, ruleID: noteDiagnosticID, uri: uri},
+		"test_directives-NewVisible":    {column: 1, level: "note", line: 27, ruleID: noteDiagnosticID, uri: uri},
+	}, actual)
+
+	// the output should be valid JSON having SARIF's property names
+	path := filepath.Join(t.TempDir(), "review.sarif")
+	require.NoError(t, r.writeSARIF(cf, path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(b, &m))
+	require.Equal(t, sarifSchema, m["$schema"])
+	require.Contains(t, m, "runs")
+}
+
+func TestReviewPosition(t *testing.T) {
+	r, err := NewReview(filepath.Clean("testdata/test_output"), nil)
+	require.NoError(t, err)
+	_, err = r.Review()
+	require.NoError(t, err)
+
+	dir := filepath.Clean("testdata/test_output")
+	for id, name := range map[string]string{
+		"test_output/subpackage.StructInline":           "StructInline",
+		"test_output/subpackage.StructInline-Options":   "StructInline.Options",
+		"test_output/subpackage.StructInline-OnRetry":   "StructInline.OnRetry",
+		"test_output/subpackage-(p *Pager[T]) NextPage": "Pager.NextPage",
+		// fields of inline structs resolve to the enclosing field
+		"test_output/subpackage.StructInline-Options-Retry": "StructInline.Options",
+	} {
+		pos, ok := r.position(id)
+		require.True(t, ok, id)
+		expected := declLocation(t, dir, "subpackage/subpackage.go", name)
+		require.Equal(t, "subpackage.go", filepath.Base(pos.Filename), id)
+		require.Equal(t, expected.Line, pos.Line, id)
+	}
+	// lines having no declaration resolve to the package clause of one of the package's files
+	pos, ok := r.position("test_output/subpackage-dependencies")
+	require.True(t, ok)
+	require.Equal(t, "subpackage", filepath.Base(filepath.Dir(pos.Filename)))
+	require.Equal(t, declLocation(t, dir, filepath.Join("subpackage", filepath.Base(pos.Filename)), "").Line, pos.Line)
+	_, ok = r.position("nonexistent.Type")
+	require.False(t, ok)
+}
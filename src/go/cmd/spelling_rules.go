@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	_ "embed"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// diagnostic messages
+const (
+	misspelledWord = "Possible misspelling: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO027", helpDocComments, checkSpelling))
+}
+
+//go:embed azure_words.txt
+var azureWordsFile string
+
+//go:embed english_words.txt
+var englishWordsFile string
+
+//go:embed misspellings.txt
+var misspellingsFile string
+
+var (
+	// dictionary is the set of correctly spelled words: English words plus Azure and SDK terms
+	dictionary = parseWords(englishWordsFile, azureWordsFile)
+
+	// misspellings maps common misspellings to their corrections e.g. "recieve" => "receive". The
+	// rule flags any word not in the dictionary; this map only adds a suggested correction.
+	misspellings = parseMisspellings(misspellingsFile)
+)
+
+// parseWords returns the set of words in the given files, which have one word per line. It
+// ignores blank lines and those beginning with "#".
+func parseWords(files ...string) map[string]bool {
+	words := map[string]bool{}
+	for _, f := range files {
+		for _, line := range strings.Split(f, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				words[strings.ToLower(line)] = true
+			}
+		}
+	}
+	return words
+}
+
+// parseMisspellings parses lines of the form "misspelling correction", ignoring blank lines and
+// those beginning with "#"
+func parseMisspellings(s string) map[string]string {
+	m := map[string]string{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if word, correction, found := strings.Cut(line, " "); found {
+			m[word] = correction
+		}
+	}
+	return m
+}
+
+var (
+	// urlRgx matches URLs, whose words spellingErrors doesn't check
+	urlRgx = regexp.MustCompile(`[a-z]+://\S+`)
+
+	// wordRgx matches the words of a comment, including contractions and possessives. Words may be
+	// identifiers, which spellingErrors splits further.
+	wordRgx = regexp.MustCompile(`[A-Za-z]+(?:'[A-Za-z]+)?`)
+)
+
+// misspelled returns whether a word from an identifier or comment is misspelled. It accepts
+// words in the dictionary or the allowed list and their regular plurals e.g. "goroutines", and
+// words unlikely to be in any dictionary: those shorter than three letters and initialisms such
+// as "SKU", which are all uppercase.
+func misspelled(word string, allowed map[string]bool) bool {
+	if len(word) < 3 || strings.ToUpper(word) == word {
+		return false
+	}
+	word = strings.ToLower(word)
+	for _, w := range []string{word, strings.TrimSuffix(word, "s")} {
+		if dictionary[w] || allowed[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// spellingErrors returns a diagnostic text for each misspelled word in s, reporting each
+// misspelling once and suggesting a correction when it's a common misspelling
+func spellingErrors(s string, allowed map[string]bool) []string {
+	texts := []string{}
+	for _, w := range wordRgx.FindAllString(urlRgx.ReplaceAllString(s, " "), -1) {
+		if stem, found := strings.CutSuffix(w, "'s"); found {
+			w = stem
+		} else if strings.Contains(w, "'") {
+			// the dictionary has no contractions
+			continue
+		}
+		for _, word := range splitWords(w) {
+			if !misspelled(word, allowed) {
+				continue
+			}
+			word = strings.ToLower(word)
+			txt := misspelledWord + word
+			if correction, ok := misspellings[word]; ok {
+				txt += " (" + correction + ")"
+			}
+			if !slices.Contains(texts, txt) {
+				texts = append(texts, txt)
+			}
+		}
+	}
+	return texts
+}
+
+// checkSpelling flags words in the names and doc comments of exported declarations that are in
+// neither the dictionary nor the config's list of words
+func checkSpelling(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	allowed := map[string]bool{}
+	for _, w := range m.config.Words {
+		allowed[strings.ToLower(w)] = true
+	}
+	for _, p := range m.reviewedPackages() {
+		for _, ident := range m.exportedIdentifiers(p) {
+			texts := spellingErrors(ident.name, allowed)
+			for _, txt := range spellingErrors(p.docs[ident.id], allowed) {
+				if !slices.Contains(texts, txt) {
+					texts = append(texts, txt)
+				}
+			}
+			for _, txt := range texts {
+				diagnostics = append(diagnostics, CodeDiagnostic{
+					Level:    CodeDiagnosticLevelInfo,
+					TargetID: ident.id,
+					Text:     txt,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// diagnostic messages
+const (
+	stdlibVersion = "Exported API requires a newer Go version than go.mod declares: "
+)
+
+func init() {
+	RegisterRule(NewRule("GO020", helpGoDirective, checkStdlibVersions))
+}
+
+var (
+	stdlibAPIOnce sync.Once
+	// stdlibAPI maps standard library symbols like "time.DateOnly" to the minor version of Go
+	// which introduced them e.g. 20 for Go 1.20
+	stdlibAPI map[string]int
+)
+
+// loadStdlibAPI returns an index of the standard library's API built from the files in $GOROOT/api.
+// The index is empty when those files aren't available.
+func loadStdlibAPI() map[string]int {
+	stdlibAPIOnce.Do(func() {
+		stdlibAPI = map[string]int{}
+		files, err := filepath.Glob(filepath.Join(build.Default.GOROOT, "api", "go1*.txt"))
+		if err != nil {
+			return
+		}
+		for _, f := range files {
+			// go1.txt lists the API of Go 1.0 and go1.N.txt the additions in Go 1.N
+			minor := 0
+			if base := filepath.Base(f); base != "go1.txt" {
+				if minor, err = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(base, "go1."), ".txt")); err != nil {
+					continue
+				}
+			}
+			if err := indexStdlibAPI(f, minor); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", f, err)
+			}
+		}
+	})
+	return stdlibAPI
+}
+
+// indexStdlibAPI adds the package-level symbols listed in an API file to stdlibAPI. Lines of
+// these files look like "pkg time, const DateOnly ideal-string #52746".
+func indexStdlibAPI(path string, minor int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		pkg, decl, found := strings.Cut(strings.TrimPrefix(scanner.Text(), "pkg "), ", ")
+		if !found {
+			continue
+		}
+		// remove any platform qualifier e.g. "syscall (linux-386)"
+		pkg, _, _ = strings.Cut(pkg, " ")
+		kind, rest, _ := strings.Cut(decl, " ")
+		switch kind {
+		case "const", "func", "type", "var":
+		default:
+			// methods and fields are available whenever their types are
+			continue
+		}
+		name := rest
+		if i := strings.IndexAny(rest, " [("); i > 0 {
+			name = rest[:i]
+		}
+		key := pkg + "." + name
+		if v, ok := stdlibAPI[key]; !ok || minor < v {
+			stdlibAPI[key] = minor
+		}
+	}
+	return scanner.Err()
+}
+
+// goMinorVersion returns the minor version of the go directive in go.mod e.g. 18 for "go 1.18.2",
+// or -1 when the module doesn't declare a version
+func (m *Module) goMinorVersion() int {
+	if m.ModFile.Go == nil {
+		return -1
+	}
+	parts := strings.Split(m.ModFile.Go.Version, ".")
+	if len(parts) < 2 {
+		return -1
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return -1
+	}
+	return minor
+}
+
+// checkStdlibVersions flags exported API referring to standard library symbols added in a newer
+// version of Go than the module's go.mod declares
+func checkStdlibVersions(m *Module) []CodeDiagnostic {
+	diagnostics := []CodeDiagnostic{}
+	minor := m.goMinorVersion()
+	if minor < 0 {
+		return diagnostics
+	}
+	api := loadStdlibAPI()
+	for _, p := range m.reviewedPackages() {
+		seen := map[string]bool{}
+		check := func(id, x string) {
+			for _, sel := range selectors(x) {
+				importPath := m.importPath(p, sel.qualifier)
+				if importPath == "" || !isStdlib(importPath) {
+					continue
+				}
+				symbol := importPath + "." + sel.name
+				if v, ok := api[symbol]; ok && v > minor && !seen[id+symbol] {
+					seen[id+symbol] = true
+					diagnostics = append(diagnostics, CodeDiagnostic{
+						Level:    CodeDiagnosticLevelError,
+						TargetID: id,
+						Text:     stdlibVersion + fmt.Sprintf("%s requires go 1.%d", symbol, v),
+					})
+				}
+			}
+		}
+		for _, ref := range m.typeRefs(p) {
+			check(ref.id, ref.t)
+		}
+		for _, decls := range []map[string]Declaration{p.c.Consts, p.c.Vars} {
+			for _, d := range decls {
+				if d.Exported() {
+					check(d.ID(), d.value)
+				}
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,124 @@
+package test_clients
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"test_clients/sub"
+)
+
+// Client follows the conventions for client methods
+type Client struct{}
+
+type ClientOptions struct {
+	azcore.ClientOptions
+}
+
+func NewClient(endpoint string, options *ClientOptions) (*Client, error) {
+	return &Client{}, nil
+}
+
+type ClientGetOptions struct{}
+
+type ClientGetResponse struct{}
+
+type ClientBeginCreateOptions struct{}
+
+type ClientCreateResponse struct{}
+
+type ClientListOptions struct{}
+
+type ClientListResponse struct{}
+
+func (c *Client) Get(ctx context.Context, name string, options *ClientGetOptions) (ClientGetResponse, error) {
+	return ClientGetResponse{}, nil
+}
+
+func (c *Client) BeginCreate(ctx context.Context, options *ClientBeginCreateOptions) (*runtime.Poller[ClientCreateResponse], error) {
+	return nil, nil
+}
+
+func (c *Client) NewListPager(options *ClientListOptions) *runtime.Pager[ClientListResponse] {
+	return nil
+}
+
+func (c *Client) NewSubClient() *SubClient {
+	return &SubClient{}
+}
+
+func (c *Client) Endpoint() string {
+	return ""
+}
+
+// SubClient breaks the conventions for client methods
+type SubClient struct{}
+
+type SubClientBeginUpdateOptions struct{}
+
+type SubClientUpdateResponse struct{}
+
+type SubClientListOptions struct{}
+
+type SubClientListResponse struct{}
+
+func (c *SubClient) Delete(name string) error {
+	return nil
+}
+
+func (c *SubClient) BeginUpdate(ctx context.Context, options *SubClientBeginUpdateOptions) (SubClientUpdateResponse, error) {
+	return SubClientUpdateResponse{}, nil
+}
+
+func (c *SubClient) NewListPager(opts *SubClientListOptions) []SubClientListResponse {
+	return nil
+}
+
+// OrphanClient has no constructor
+type OrphanClient struct{}
+
+// NoOptionsClient's constructor takes no options
+type NoOptionsClient struct{}
+
+func NewNoOptionsClient(endpoint string) *NoOptionsClient {
+	return &NoOptionsClient{}
+}
+
+// PlainOptionsClient's options don't embed azcore.ClientOptions
+type PlainOptionsClient struct{}
+
+type PlainOptionsClientOptions struct {
+	Retries int
+}
+
+func NewPlainOptionsClient(endpoint string, options *PlainOptionsClientOptions) *PlainOptionsClient {
+	return &PlainOptionsClient{}
+}
+
+// ARMClient follows the conventions for ARM clients
+type ARMClient struct{}
+
+func NewARMClient(subscriptionID string, options *arm.ClientOptions) (*ARMClient, error) {
+	return &ARMClient{}, nil
+}
+
+type SubClientGetOptions struct{}
+
+type SubClientGetResponse struct{}
+
+type SubClientBeginPurgeOptions struct{}
+
+type SubClientPurgeResponse struct{}
+
+// Get and BeginPurge are operations despite not returning errors
+func (c *SubClient) Get(ctx context.Context, options *SubClientGetOptions) SubClientGetResponse {
+	return SubClientGetResponse{}
+}
+
+func (c *SubClient) BeginPurge(ctx context.Context, options *SubClientBeginPurgeOptions) *runtime.Poller[SubClientPurgeResponse] {
+	return nil
+}
+
+// AliasedClient's constructor is in package sub
+type AliasedClient = sub.AliasedClient
@@ -0,0 +1,3 @@
+module test_clients
+
+go 1.18
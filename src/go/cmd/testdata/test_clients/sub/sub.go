@@ -0,0 +1,20 @@
+package sub
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// AliasedClient is exported by alias from test_clients. Rules flag its methods only here.
+type AliasedClient struct{}
+
+type AliasedClientOptions struct {
+	azcore.ClientOptions
+}
+
+func NewAliasedClient(endpoint string, options *AliasedClientOptions) (*AliasedClient, error) {
+	return &AliasedClient{}, nil
+}
+
+func (c *AliasedClient) Delete() error {
+	return nil
+}
@@ -0,0 +1,3 @@
+module test_config
+
+go 1.18
@@ -0,0 +1,15 @@
+package test_config
+
+type unexportedStruct struct{}
+
+type Embedder struct {
+	unexportedStruct
+}
+
+type SuppressedEmbedder struct {
+	unexportedStruct
+}
+
+type Sealed interface {
+	foo()
+}
@@ -0,0 +1,10 @@
+module github.com/Azure/azure-sdk-for-go/sdk/widgets/azwidgets
+
+go 1.18
+
+require (
+	github.com/Azure/azure-sdk-for-go/sdk/azcore v1.11.0
+	github.com/Azure/azure-sdk-for-go/sdk/widgets/internal v0.1.0
+	github.com/google/uuid v1.6.0
+	github.com/other/allowed v1.0.0
+)
@@ -0,0 +1,27 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azwidgets
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/widgets/internal/shared"
+	"github.com/google/uuid"
+	"github.com/other/allowed/thing"
+)
+
+type Client struct{}
+
+func (c *Client) Get(ctx context.Context, id uuid.UUID, options *policy.RequestOptions) (thing.Thing, error) {
+	return thing.Thing{}, nil
+}
+
+type Widget struct {
+	Created *time.Time
+	ID      *uuid.UUID
+	Shared  *shared.Value
+	seed    uuid.UUID
+}
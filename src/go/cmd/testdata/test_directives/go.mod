@@ -0,0 +1,3 @@
+module test_directives
+
+go 1.18
@@ -0,0 +1,40 @@
+package test_directives
+
+type unexportedStruct struct{}
+
+// Embedder embeds an unexported struct.
+//
+//apiview:suppress GO002 embedding is intentional
+type Embedder struct {
+	unexportedStruct
+}
+
+//apiview:hide
+type Hidden struct {
+	Field string
+}
+
+type Visible struct {
+	Shown string
+	//apiview:hide
+	Secret string
+	Noted  int //apiview:note Noted counts things
+}
+
+// NewVisible creates a Visible.
+//
+//apiview:note prefer a composite literal
+func NewVisible() *Visible {
+	return &Visible{}
+}
+
+const (
+	//apiview:bogus
+	Constant = 1
+)
+
+//apiview:hide
+//apiview:note this note is hidden with its target
+type HiddenNoted struct {
+	Bad_Name int
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_enums
+
+import (
+	"test_enums/sub"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/log"
+)
+
+type Color string
+
+const (
+	ColorBlue Color = "blue"
+	ColorRed  Color = "red"
+)
+
+type Shape int
+
+const (
+	ShapeCircle Shape = iota
+	ShapeSquare
+)
+
+func PossibleShapeValues() []Shape {
+	return []Shape{ShapeCircle, ShapeSquare}
+}
+
+type Size string
+
+func PossibleSizeValues() []Size {
+	return nil
+}
+
+const EventRequest log.Event = "Request"
+
+// KindB belongs in package sub, which defines the Kind enum
+const KindB sub.Kind = "b"
+
+// types of other modules and the standard library aren't the module's enums
+const DefaultTimeout time.Duration = 30 * time.Second
+
+const DefaultLevel sub.Level = 1
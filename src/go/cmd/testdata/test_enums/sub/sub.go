@@ -0,0 +1,15 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package sub
+
+type Kind string
+
+const KindA Kind = "a"
+
+func PossibleKindValues() []Kind {
+	return []Kind{KindA}
+}
+
+// Level has no constants, so it isn't an enum
+type Level int
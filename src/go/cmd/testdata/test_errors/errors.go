@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+type CauseError struct {
+	cause error
+}
+
+func (c *CauseError) Error() string {
+	return c.cause.Error()
+}
+
+func (c *CauseError) Unwrap() error {
+	return c.cause
+}
+
+type Code int
+
+func (c Code) Error() string {
+	return fmt.Sprint(int(c))
+}
+
+type Failure struct {
+	Reason string
+}
+
+func (f Failure) Error() string {
+	return f.Reason
+}
+
+type ResponseError struct {
+	StatusCode int
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprint(e.StatusCode)
+}
+
+type WrapError struct {
+	inner error
+}
+
+func (w *WrapError) Error() string {
+	return w.inner.Error()
+}
+
+var (
+	ErrClosed = errors.New("closed")
+	NotFound  = fmt.Errorf("not found")
+	Timeout   error
+)
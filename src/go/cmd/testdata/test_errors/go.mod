@@ -0,0 +1,3 @@
+module test_errors
+
+go 1.18
@@ -0,0 +1,3 @@
+module example.com/test-hyphenated-module
+
+go 1.18
@@ -0,0 +1,14 @@
+package hyphenated
+
+type Client struct{}
+
+func (c *Client) Do() error {
+	return nil
+}
+
+type Pager[T any] struct{}
+
+func (p *Pager[T]) NextPage() (T, error) {
+	var t T
+	return t, nil
+}
@@ -0,0 +1,16 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_models
+
+type WidgetKind string
+
+const (
+	WidgetKindLarge WidgetKind = "large"
+	WidgetKindSmall WidgetKind = "small"
+)
+
+// Settings isn't a model because it's neither in models.go nor has JSON methods
+type Settings struct {
+	Verbose bool
+}
@@ -0,0 +1,3 @@
+module test_models
+
+go 1.18
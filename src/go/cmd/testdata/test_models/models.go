@@ -0,0 +1,32 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_models
+
+import "time"
+
+type Gadget struct {
+	Count      *int64
+	Kind       WidgetKind
+	Name       *string
+	Properties *GadgetProperties
+}
+
+type Widget struct {
+	Count   *int32
+	Created *time.Time
+	Kind    *WidgetKind
+	Name       string
+	Properties *WidgetProperties
+	Tags       []*string
+}
+
+// GadgetProperties and WidgetProperties are specific to their models, so Gadget.Properties
+// and Widget.Properties having different types isn't drift
+type GadgetProperties struct {
+	Weight *float64
+}
+
+type WidgetProperties struct {
+	Size *int32
+}
@@ -0,0 +1,11 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package timed
+
+import "time"
+
+type Deadline struct {
+	Due  *time.Time
+	Tags []string
+}
@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package timed
+
+import "time"
+
+type dateTimeRFC3339 time.Time
+
+type Event struct {
+	Start *time.Time
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
+
+type Schedule struct {
+	End *time.Time
+}
+
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	return nil
+}
@@ -0,0 +1,3 @@
+module test_mutability
+
+go 1.18
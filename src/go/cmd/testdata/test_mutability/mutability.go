@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_mutability
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+func CopyCache() Cache {
+	return Cache{}
+}
+
+type Group struct {
+	sync.WaitGroup
+}
+
+type Tracker struct {
+	Lock sync.RWMutex
+}
+
+type Options struct {
+	Retries int
+}
+
+var (
+	DefaultClient  *http.Client
+	DefaultHeaders = map[string]string{}
+	DefaultOptions = &Options{}
+	ErrNotFound    = errors.New("not found")
+	Regions        = make([]string, 0)
+	Zones          [3]string
+)
@@ -0,0 +1,3 @@
+module test_naming
+
+go 1.18
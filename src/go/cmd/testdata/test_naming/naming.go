@@ -0,0 +1,27 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_naming
+
+type BlobType string
+
+const (
+	BlobTypeBlock BlobType = "block"
+	PageBlob      BlobType = "page"
+)
+
+type Item struct {
+	ItemIds  []string
+	OwnerURL string
+	Raw_Data []byte
+}
+
+type Test_namingClient struct{}
+
+func (c *Test_namingClient) GetHttpStatus() int {
+	return 0
+}
+
+func NewJsonItem() Item {
+	return Item{}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package widgets is in a directory having a different name
+package widgets
+
+// WidgetsOptions stutters because its name begins with the package's name
+type WidgetsOptions struct{}
+
+// WidgetapiOptions doesn't stutter because its name begins with the directory's name
+type WidgetapiOptions struct{}
@@ -0,0 +1,10 @@
+package subpackage
+
+// Failure is an error type flagged for its name, its value receiver and its missing Unwrap method
+type Failure struct {
+	Err error
+}
+
+func (f Failure) Error() string {
+	return f.Err.Error()
+}
@@ -0,0 +1,8 @@
+package subpackage
+
+import "sync"
+
+// Guarded holds a mutex by value, which callers mustn't copy
+type Guarded struct {
+	mu sync.Mutex
+}
@@ -0,0 +1,10 @@
+package subpackage
+
+// Pair's methods name their *Pair parameters inconsistently
+type Pair struct{}
+
+func (p *Pair) Copy(src *Pair) {}
+
+func (p *Pair) Merge(other *Pair) {}
+
+func (p *Pair) Swap(other *Pair) {}
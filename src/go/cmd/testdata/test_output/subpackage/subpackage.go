@@ -126,3 +126,65 @@ func NewEnumPointer() *Enum {
 }
 
 const String = "string"
+
+type Number interface {
+	~int | ~int64 | float64
+}
+
+type Stringish interface {
+	~string
+	String() string
+}
+
+func Sum[T Number](vals ...T) T {
+	var sum T
+	for _, v := range vals {
+		sum += v
+	}
+	return sum
+}
+
+type Pager[T any] struct {
+	current T
+}
+
+func NewPager[T any](first T) *Pager[T] {
+	return &Pager[T]{current: first}
+}
+
+func (p *Pager[T]) NextPage() (T, error) {
+	return p.current, nil
+}
+
+type StructInline struct {
+	Options struct {
+		Retry   int
+		Timeout *int
+		hidden  bool
+	}
+	OnRetry func(attempt int, err error) bool
+}
+
+type Celsius float64
+
+// Temperature's only term is a defined type, which makes it a constraint
+type Temperature interface {
+	Celsius
+}
+
+// EmbedsInterface embeds an interface, which doesn't make it a constraint
+type EmbedsInterface interface {
+	Interface
+}
+
+type StructInlineShapes struct {
+	ByName map[string]*struct {
+		Z int
+	}
+	Items []struct {
+		X int
+	}
+	Ptr *struct {
+		Y int
+	}
+}
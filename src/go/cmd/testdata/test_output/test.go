@@ -46,3 +46,13 @@ type doNotShowFunc func()
 type doNotShowEnum string
 
 const doNotShowEnumValue doNotShowEnum = "..."
+
+type Number = subpackage.Number
+
+type Stringish = subpackage.Stringish
+
+type Guarded = subpackage.Guarded
+
+type Failure = subpackage.Failure
+
+type Pair = subpackage.Pair
@@ -0,0 +1,3 @@
+module test_params
+
+go 1.18
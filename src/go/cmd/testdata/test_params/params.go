@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_params
+
+import (
+	"context"
+	"time"
+)
+
+type Client struct{}
+
+func (c *Client) Do(ctx context.Context, r *Request) error {
+	return nil
+}
+
+func (c *Client) Forward(ctx context.Context, req *Request) error {
+	return nil
+}
+
+func (c *Client) Retry(ctx context.Context, timeout time.Duration) error {
+	return nil
+}
+
+func (c *Client) Send(ctx context.Context, req *Request) error {
+	return nil
+}
+
+func (c *Client) Sleep(ctx context.Context, timeout int) {}
+
+func (c *Client) Wait(cx context.Context, timeout time.Duration) error {
+	return nil
+}
+
+type Request struct{}
+
+func Get(name string) {}
+
+func Merge(dst, src *Request) {}
+
+func Put(key string) {}
+
+type Sender interface {
+	Send(c context.Context, req *Request) error
+}
+
+func CreateGroup(resourceGroupName string) {}
+
+func DeleteGroup(resourceGroupName string) {}
+
+func GetGroup(rgName string) {}
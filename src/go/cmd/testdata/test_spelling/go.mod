@@ -0,0 +1,3 @@
+module test_spelling
+
+go 1.18
@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_spelling
+
+// Message is a message to recieve.
+type Message struct {
+	// Adress is where the message was sent
+	Adress string
+	// Body is the message's content. It isn't succesful to recieve an empty body.
+	Body []byte
+}
+
+// RecieveMessage can retreive a message.
+//
+//apiview:note the name is intentional
+func RecieveMessage() Message {
+	return Message{}
+}
+
+// seperate isn't exported, so its name and comment aren't checked
+func seperate() {}
+
+// Reciept confirms a Subscripton's payment. Terms such as "etag" and "goroutines" are spelled correctly.
+type Reciept struct {
+	// SubscriptonID identifies the subscription
+	SubscriptonID string
+}
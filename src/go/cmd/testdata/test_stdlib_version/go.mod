@@ -0,0 +1,3 @@
+module test_stdlib_version
+
+go 1.18
@@ -0,0 +1,27 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_stdlib_version
+
+import (
+	"iter"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const Layout = time.DateOnly
+
+type Options struct {
+	Client  *http.Client
+	Level   slog.Level
+	Timeout time.Duration
+}
+
+func Levels() iter.Seq[slog.Level] {
+	return nil
+}
+
+func Pairs() iter.Seq2[string, slog.Level] {
+	return nil
+}
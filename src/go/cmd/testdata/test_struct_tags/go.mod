@@ -0,0 +1,3 @@
+module test_struct_tags
+
+go 1.18
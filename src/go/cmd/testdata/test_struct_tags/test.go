@@ -0,0 +1,12 @@
+package test_struct_tags
+
+type Model struct {
+	Count             *int32  `json:"count,omitempty"`
+	Location          *string `json:"location"`
+	Name              *string `json:"name,omitempty" xml:"Name"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+	ResourceID        *string `json:"resource_id,omitempty"`
+	TimeCreated       string  `json:"timeCreated"`
+	Untagged          string
+	unexported        *string `json:"unexported"`
+}
@@ -0,0 +1,3 @@
+module test_type_refs
+
+go 1.18
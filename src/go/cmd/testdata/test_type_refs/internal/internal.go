@@ -0,0 +1,10 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package internal
+
+type Aliased struct{}
+
+type Base struct{}
+
+type Options struct{}
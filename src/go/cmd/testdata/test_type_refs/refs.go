@@ -0,0 +1,33 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package test_type_refs
+
+import (
+	"context"
+
+	"test_type_refs/internal"
+)
+
+type Aliased = internal.Aliased
+
+type Box[T any] struct {
+	Item T
+}
+
+type Holder struct {
+	internal.Base
+	Handler func(ctx context.Context, v value) error
+}
+
+type value struct{}
+
+func NewThing() *thing {
+	return nil
+}
+
+func Use(opts *internal.Options) {}
+
+func UseAliased(a internal.Aliased) {}
+
+type thing struct{}
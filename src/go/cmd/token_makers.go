@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"regexp"
 	"sort"
 	"strings"
@@ -32,9 +33,12 @@ type TokenMaker interface {
 type Declaration struct {
 	Type string
 
-	id    string
-	name  string
-	value string
+	id   string
+	name string
+	pos  token.Position
+	// typePkg is the import path of the package defining Type, when that isn't the declaring package
+	typePkg string
+	value   string
 }
 
 func NewDeclaration(pkg Pkg, vs *ast.ValueSpec, imports map[string]string) Declaration {
@@ -42,7 +46,7 @@ func NewDeclaration(pkg Pkg, vs *ast.ValueSpec, imports map[string]string) Decla
 	if len(vs.Values) > 0 {
 		v = getExprValue(pkg, vs.Values[0])
 	}
-	decl := Declaration{id: pkg.Name() + "." + vs.Names[0].Name, name: vs.Names[0].Name, value: v}
+	decl := Declaration{id: pkg.Name() + "." + vs.Names[0].Name, name: vs.Names[0].Name, pos: pkg.fs.Position(vs.Pos()), value: v}
 	// Type is nil for untyped consts
 	if vs.Type != nil {
 		switch x := vs.Type.(type) {
@@ -56,6 +60,9 @@ func NewDeclaration(pkg Pkg, vs *ast.ValueSpec, imports map[string]string) Decla
 		case *ast.SelectorExpr:
 			// const LogCredential log.Classification = "Credential"
 			decl.Type = pkg.translateType(x.Sel.Name, imports)
+			if ident, ok := x.X.(*ast.Ident); ok {
+				decl.typePkg = imports[ident.Name]
+			}
 		case *ast.StarExpr:
 			switch xX := x.X.(type) {
 			case *ast.Ident:
@@ -65,10 +72,10 @@ func NewDeclaration(pkg Pkg, vs *ast.ValueSpec, imports map[string]string) Decla
 				// var defaultHTTPClient *http.Client
 				decl.Type = pkg.translateType(fmt.Sprintf("*%s.%s", xX.X, xX.Sel.Name), imports)
 			default:
-				fmt.Printf("unhandled declaration type %T for %s\n", xX, pkg.getText(vs.Type.Pos(), vs.Type.End()))
+				pkg.warn("unhandled declaration type %T for %s", xX, pkg.getText(vs.Type.Pos(), vs.Type.End()))
 			}
 		default:
-			fmt.Println("unhandled declaration " + pkg.getText(vs.Type.Pos(), vs.Type.End()))
+			pkg.warn("unhandled declaration %s", pkg.getText(vs.Type.Pos(), vs.Type.End()))
 		}
 	} else if len(vs.Values) == 1 {
 		switch t := vs.Values[0].(type) {
@@ -142,6 +149,22 @@ type Func struct {
 	paramNames []string
 	// paramTypes lists the func's parameters type
 	paramTypes []string
+	// pkgName is the name of the package whose review includes the func e.g. "azcore/runtime"
+	pkgName string
+	// pos is the func's position in source
+	pos token.Position
+	// receiverBaseType is the name of the receiver's type without any pointer or type parameters
+	// e.g. "Pager" for a receiver of type "*Pager[T]"
+	receiverBaseType string
+	// receiverPointer indicates whether the receiver is a pointer
+	receiverPointer bool
+	// receiverTypeParams lists the names the receiver gives its type's parameters e.g. "T" for "*Pager[T]"
+	receiverTypeParams []string
+	// sig is the func's ID without its package name e.g. "(c *Client) Do"
+	sig string
+	// returnBaseTypes lists the names of the func's return types without any pointer or type
+	// arguments e.g. "Pager" for "*Pager[T]". It has the same length as Returns.
+	returnBaseTypes []string
 	// typeParamNames lists the func's type parameters name
 	typeParamNames []string
 	// typeParamConstraints lists the func's type parameters constraint
@@ -151,12 +174,31 @@ type Func struct {
 func NewFunc(pkg Pkg, f *ast.FuncDecl, imports map[string]string) Func {
 	fn := newFunc(pkg, f.Type, imports)
 	fn.name = f.Name.Name
+	fn.pos = pkg.fs.Position(f.Pos())
 	sig := ""
 	if f.Recv != nil {
-		fn.ReceiverType = pkg.getText(f.Recv.List[0].Type.Pos(), f.Recv.List[0].Type.End())
+		recv := f.Recv.List[0].Type
+		fn.ReceiverType = pkg.getText(recv.Pos(), recv.End())
 		if len(f.Recv.List[0].Names) != 0 {
 			fn.ReceiverName = f.Recv.List[0].Names[0].Name
 		}
+		if star, ok := recv.(*ast.StarExpr); ok {
+			fn.receiverPointer = true
+			recv = star.X
+		}
+		fn.receiverBaseType, fn.receiverTypeParams = baseTypeName(recv)
+		// the receiver's type parameters are declared by the receiver's type, so they
+		// should navigate to that type rather than to nonexistent package-level types
+		for _, tp := range fn.receiverTypeParams {
+			from := fmt.Sprintf("<%s.%s>", pkg.Name(), tp)
+			to := fmt.Sprintf("<%s.%s>", pkg.Name(), fn.receiverBaseType)
+			for i := range fn.paramTypes {
+				fn.paramTypes[i] = strings.ReplaceAll(fn.paramTypes[i], from, to)
+			}
+			for i := range fn.Returns {
+				fn.Returns[i] = strings.ReplaceAll(fn.Returns[i], from, to)
+			}
+		}
 		if fn.ReceiverName != "" {
 			sig = fmt.Sprintf("(%s %s) ", fn.ReceiverName, fn.ReceiverType)
 		} else {
@@ -165,15 +207,18 @@ func NewFunc(pkg Pkg, f *ast.FuncDecl, imports map[string]string) Func {
 	}
 	fn.exported = !isOnUnexportedMember(sig) && unicode.IsUpper(rune(fn.name[0]))
 	sig += f.Name.Name
-	fn.id = pkg.Name() + "-" + sig
+	fn.pkgName, fn.sig = pkg.Name(), sig
+	fn.id = fn.pkgName + "-" + fn.sig
 	return fn
 }
 
 func NewFuncForInterfaceMethod(pkg Pkg, interfaceName string, f *ast.Field, imports map[string]string) Func {
 	fn := newFunc(pkg, f.Type.(*ast.FuncType), imports)
 	fn.name = f.Names[0].Name
+	fn.pos = pkg.fs.Position(f.Pos())
 	fn.exported = unicode.IsUpper(rune(fn.name[0]))
-	fn.id = pkg.Name() + "-" + interfaceName + "-" + fn.name
+	fn.pkgName, fn.sig = pkg.Name(), interfaceName+"-"+fn.name
+	fn.id = fn.pkgName + "-" + fn.sig
 	fn.embedded = true
 	return fn
 }
@@ -205,10 +250,54 @@ func newFunc(pkg Pkg, f *ast.FuncType, imports map[string]string) Func {
 		pkg.translateFieldList(f.Results.List, func(n *string, t string) {
 			fn.Returns = append(fn.Returns, pkg.translateType(t, imports))
 		})
+		fn.returnBaseTypes = make([]string, 0, len(fn.Returns))
+		for _, r := range f.Results.List {
+			x := r.Type
+			if star, ok := x.(*ast.StarExpr); ok {
+				x = star.X
+			}
+			base, _ := baseTypeName(x)
+			// a field having several names e.g. "(a, b int)" declares several return values
+			for i := 0; i < max(1, len(r.Names)); i++ {
+				fn.returnBaseTypes = append(fn.returnBaseTypes, base)
+			}
+		}
 	}
 	return fn
 }
 
+// baseTypeName returns the name of the type expressed by x, without type arguments, and the
+// names of any type arguments that are identifiers. For example, given "Pager[T]" it returns
+// "Pager" and ["T"]. It returns a qualified name such as "runtime.Pager" for a type from
+// another package, and an empty name when x doesn't name a type.
+func baseTypeName(x ast.Expr) (string, []string) {
+	var args []ast.Expr
+	switch t := x.(type) {
+	case *ast.IndexExpr:
+		// "Pager[T]"
+		x, args = t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		// "Pager[K, V]"
+		x, args = t.X, t.Indices
+	}
+	name := ""
+	switch t := x.(type) {
+	case *ast.Ident:
+		name = t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			name = pkg.Name + "." + t.Sel.Name
+		}
+	}
+	params := []string{}
+	for _, arg := range args {
+		if ident, ok := arg.(*ast.Ident); ok {
+			params = append(params, ident.Name)
+		}
+	}
+	return name, params
+}
+
 func (f Func) Exported() bool {
 	return f.exported
 }
@@ -219,12 +308,8 @@ func (f Func) ID() string {
 
 func (f Func) ForAlias(pkg string) Func {
 	clone := f
-	// replace everything to the left of - with the new package name
-	i := strings.Index(clone.id, "-")
-	if i < 0 {
-		panic("missing sig separator in id")
-	}
-	clone.id = pkg + clone.id[i:]
+	clone.pkgName = pkg
+	clone.id = pkg + "-" + clone.sig
 	return clone
 }
 
@@ -241,7 +326,8 @@ func (f Func) MakeTokens() []ReviewToken {
 	// prefix with "func" if f isn't embedded in an interface
 	if !f.embedded {
 		tks = append(tks, ReviewToken{
-			HasSuffixSpace: true,
+			// a nameless func is a func type e.g. "func(int) bool", which has no space before its params
+			HasSuffixSpace: f.name != "",
 			Kind:           TokenKindKeyword,
 			Value:          "func",
 		})
@@ -251,17 +337,23 @@ func (f Func) MakeTokens() []ReviewToken {
 			Kind:  TokenKindText,
 			Value: "(",
 		})
-		tks = append(tks, parseAndMakeTypeTokens(f.ReceiverType)...)
+		if f.receiverBaseType != "" {
+			tks = append(tks, f.makeReceiverTokens()...)
+		} else {
+			tks = append(tks, parseAndMakeTypeTokens(f.ReceiverType)...)
+		}
 		tks = append(tks, ReviewToken{
 			HasSuffixSpace: true,
 			Kind:           TokenKindPunctuation,
 			Value:          ")",
 		})
 	}
-	tks = append(tks, ReviewToken{
-		Kind:  TokenKindTypeName,
-		Value: f.name,
-	})
+	if f.name != "" {
+		tks = append(tks, ReviewToken{
+			Kind:  TokenKindTypeName,
+			Value: f.name,
+		})
+	}
 	if len(f.typeParamNames) > 0 {
 		tks = append(tks, ReviewToken{
 			Kind:  TokenKindPunctuation,
@@ -351,6 +443,41 @@ func (f Func) MakeTokens() []ReviewToken {
 	return tks
 }
 
+// makeReceiverTokens returns tokens for the receiver's type, linking the type and its
+// parameters e.g. "T" in "*Pager[T]" to the type's declaration
+func (f Func) makeReceiverTokens() []ReviewToken {
+	// the type is declared in the same package as the method
+	typeID := f.pkgName + "." + f.receiverBaseType
+	tks := []ReviewToken{}
+	if f.receiverPointer {
+		tks = append(tks, ReviewToken{Kind: TokenKindPunctuation, Value: "*"})
+	}
+	tks = append(tks, ReviewToken{
+		Kind:         TokenKindTypeName,
+		NavigateToID: typeID,
+		Value:        f.receiverBaseType,
+	})
+	if len(f.receiverTypeParams) > 0 {
+		tks = append(tks, ReviewToken{Kind: TokenKindPunctuation, Value: "["})
+		for i, tp := range f.receiverTypeParams {
+			if i > 0 {
+				tks = append(tks, ReviewToken{
+					HasSuffixSpace: true,
+					Kind:           TokenKindPunctuation,
+					Value:          ",",
+				})
+			}
+			tks = append(tks, ReviewToken{
+				Kind:         TokenKindTypeName,
+				NavigateToID: typeID,
+				Value:        tp,
+			})
+		}
+		tks = append(tks, ReviewToken{Kind: TokenKindPunctuation, Value: "]"})
+	}
+	return tks
+}
+
 func (f Func) Name() string {
 	return f.name
 }
@@ -359,12 +486,17 @@ var _ TokenMaker = (*Func)(nil)
 
 type Interface struct {
 	TokenMaker
+	// Constraint indicates whether the interface has type set elements such as "~string | ~int",
+	// which means it can be used only as a type parameter constraint
+	Constraint bool
 	// Sealed indicates whether users can implement the interface i.e. whether it has an unexported method
 	Sealed             bool
 	embeddedInterfaces []string
 	id                 string
 	methods            map[string]Func
 	name               string
+	// typeSet lists the interface's type set elements e.g. "~string | ~int"
+	typeSet []string
 }
 
 func NewInterface(source Pkg, name, packageName string, n *ast.InterfaceType, imports map[string]string) Interface {
@@ -385,14 +517,39 @@ func NewInterface(source Pkg, name, packageName string, n *ast.InterfaceType, im
 				in.methods[n] = f
 			} else {
 				n := source.getText(m.Type.Pos(), m.Type.End())
-				in.embeddedInterfaces = append(in.embeddedInterfaces, source.translateType(n, imports))
+				if isTypeSetElement(m.Type) {
+					in.typeSet = append(in.typeSet, source.translateType(n, imports))
+				} else {
+					in.embeddedInterfaces = append(in.embeddedInterfaces, source.translateType(n, imports))
+				}
 			}
 		}
 	}
+	in.Constraint = len(in.typeSet) > 0
 	sort.Strings(in.embeddedInterfaces)
+	sort.Strings(in.typeSet)
 	return in
 }
 
+// isTypeSetElement returns true when the given embedded interface element is a type set element
+// e.g. "~string", "int | float64" or "comparable" rather than an embedded interface
+func isTypeSetElement(x ast.Expr) bool {
+	switch t := x.(type) {
+	case *ast.BinaryExpr:
+		// "~int | ~string"
+		return t.Op == token.OR
+	case *ast.UnaryExpr:
+		// "~string"
+		return t.Op == token.TILDE
+	case *ast.Ident:
+		// "int" or "comparable"; "error" is an interface, so embedding it is fine outside constraints
+		return t.Name == "comparable" || (t.Name != "error" && slices.Contains(internalTypes, t.Name))
+	case *ast.ArrayType, *ast.ChanType, *ast.FuncType, *ast.MapType, *ast.StarExpr, *ast.StructType:
+		return true
+	}
+	return false
+}
+
 func (i Interface) Exported() bool {
 	return unicode.IsUpper(rune(i.name[0]))
 }
@@ -424,8 +581,15 @@ func (i Interface) MakeReviewLine() ReviewLine {
 		},
 	}
 
+	for _, elem := range i.typeSet {
+		interfaceLine.Children = append(interfaceLine.Children, ReviewLine{
+			Tokens: parseAndMakeTypeTokens(elem),
+		})
+	}
+
 	for _, name := range i.embeddedInterfaces {
-		if exportedFieldRgx.MatchString(name) {
+		// names of the module's types have navigators e.g. "<pkg.Iface>Iface"
+		if exportedFieldRgx.MatchString(stripNavigators(name)) {
 			// TODO: set NavigateToID to create a navigation link. Need the type's LineID i.e. qualified
 			// name but we don't have it here; similar to https://github.com/Azure/azure-sdk-tools/issues/6150
 			interfaceLine.Children = append(interfaceLine.Children, ReviewLine{
@@ -504,10 +668,27 @@ var _ TokenMaker = (*SimpleType)(nil)
 
 type Struct struct {
 	AnonymousFields []string
+	// embeddedTypes lists the types of anonymous fields with navigators, as translateType returns them
+	embeddedTypes []string
 	// fields maps a field's name to the name of its type
 	fields map[string]string
-	id     string
-	name   string
+	// fieldPos maps a field's name to its position in source
+	fieldPos map[string]token.Position
+	// funcFields maps the name of a field having a func type to that type's signature
+	funcFields map[string]Func
+	id         string
+	name       string
+	// nested maps the name of a field having an anonymous struct type to that struct
+	nested map[string]Struct
+	// opts determines the layout of fields and how the review displays tags
+	opts ReviewOptions
+	// pos is the struct's position in source
+	pos token.Position
+	// tags maps a field's name to its tag, including the enclosing quotes e.g. `json:"name"`
+	tags map[string]string
+	// typePrefix precedes "struct" in the type of the field having this anonymous struct
+	// type e.g. "[]*" for a field of type []*struct{...}. It's empty for other structs.
+	typePrefix string
 	// typeParams lists the func's type parameters as strings of the form "name constraint"
 	typeParams []string
 	pkgName    string
@@ -515,13 +696,20 @@ type Struct struct {
 
 func (s Struct) MakeReviewLine() ReviewLine {
 	structLine := ReviewLine{
-		Children: []ReviewLine{},
+		Children: s.makeFieldLines(),
 		LineID:   s.id,
 		Tokens:   s.MakeTokens(),
 	}
+	return structLine
+}
+
+// makeFieldLines returns a ReviewLine for each of the struct's exported fields. Fields having
+// anonymous struct types get child lines for their own fields.
+func (s Struct) makeFieldLines() []ReviewLine {
+	lines := []ReviewLine{}
 	for _, field := range s.AnonymousFields {
 		if exportedFieldRgx.MatchString(field) {
-			structLine.Children = append(structLine.Children, ReviewLine{
+			lines = append(lines, ReviewLine{
 				Tokens: []ReviewToken{
 					{
 						Kind: TokenKindTypeName,
@@ -544,7 +732,7 @@ func (s Struct) MakeReviewLine() ReviewLine {
 		}
 	}
 	if len(exported) > 0 {
-		sort.Strings(exported)
+		sortNames(exported, s.opts.Layout, func(name string) token.Position { return s.fieldPos[name] })
 		for _, name := range exported {
 			fieldLine := ReviewLine{
 				LineID: s.id + "-" + name,
@@ -562,34 +750,115 @@ func (s Struct) MakeReviewLine() ReviewLine {
 					},
 				},
 			}
-			typeTks := parseAndMakeTypeTokens(s.fields[name])
-			fieldLine.Tokens = append(fieldLine.Tokens, typeTks...)
-			structLine.Children = append(structLine.Children, fieldLine)
+			if nested, ok := s.nested[name]; ok {
+				if nested.typePrefix != "" {
+					fieldLine.Tokens = append(fieldLine.Tokens, parseAndMakeTypeTokens(nested.typePrefix)...)
+				}
+				fieldLine.Tokens = append(fieldLine.Tokens, ReviewToken{
+					Kind:  TokenKindKeyword,
+					Value: "struct",
+				})
+				nested.opts = s.opts
+				fieldLine.Children = nested.makeFieldLines()
+			} else if fn, ok := s.funcFields[name]; ok {
+				fieldLine.Tokens = append(fieldLine.Tokens, fn.MakeTokens()...)
+			} else {
+				typeTks := parseAndMakeTypeTokens(s.fields[name])
+				fieldLine.Tokens = append(fieldLine.Tokens, typeTks...)
+			}
+			if tag, ok := s.tags[name]; ok && (s.opts.StructTags == StructTagsShown || s.opts.StructTags == StructTagsSkipDiff) {
+				fieldLine.Tokens[len(fieldLine.Tokens)-1].HasSuffixSpace = true
+				fieldLine.Tokens = append(fieldLine.Tokens, ReviewToken{
+					Kind:     TokenKindStringLiteral,
+					SkipDiff: s.opts.StructTags == StructTagsSkipDiff,
+					Value:    tag,
+				})
+			}
+			lines = append(lines, fieldLine)
 		}
 	}
-	return structLine
+	return lines
 }
 
 func NewStruct(source Pkg, name, packageName string, ts *ast.TypeSpec, imports map[string]string) Struct {
-	s := Struct{name: name, id: packageName + "." + name, pkgName: source.Name()}
+	s := Struct{name: name, id: packageName + "." + name, pkgName: source.Name(), pos: source.fs.Position(ts.Pos())}
 	if ts.TypeParams != nil {
 		s.typeParams = make([]string, 0, len(ts.TypeParams.List))
 		source.translateFieldList(ts.TypeParams.List, func(param *string, constraint string) {
 			s.typeParams = append(s.typeParams, strings.TrimRight(*param+" "+source.translateType(constraint, imports), " "))
 		})
 	}
-	source.translateFieldList(ts.Type.(*ast.StructType).Fields.List, func(n *string, t string) {
-		if n == nil {
+	s.addFields(source, ts.Type.(*ast.StructType).Fields.List, imports)
+	return s
+}
+
+// addFields adds the given fields to the struct. Fields having anonymous struct types, including
+// those within slice, array, pointer and map types, become nested Structs whose IDs are qualified
+// by the field's ID.
+func (s *Struct) addFields(source Pkg, fields []*ast.Field, imports map[string]string) {
+	for _, f := range fields {
+		t := source.getText(f.Type.Pos(), f.Type.End())
+		if len(f.Names) == 0 {
 			s.AnonymousFields = append(s.AnonymousFields, t)
-		} else {
-			if s.fields == nil {
-				s.fields = map[string]string{}
+			s.embeddedTypes = append(s.embeddedTypes, source.translateType(t, imports))
+			continue
+		}
+		if s.fields == nil {
+			s.fields = map[string]string{}
+		}
+		for _, n := range f.Names {
+			name := n.Name
+			switch ft := anonymousStruct(f.Type).(type) {
+			case *ast.StructType:
+				// Options struct { Retry int } or Items []struct { X int }
+				nested := Struct{id: s.id + "-" + name, pkgName: s.pkgName}
+				nested.typePrefix = source.translateType(source.getText(f.Type.Pos(), ft.Pos()), imports)
+				nested.addFields(source, ft.Fields.List, imports)
+				if s.nested == nil {
+					s.nested = map[string]Struct{}
+				}
+				s.nested[name] = nested
+			case *ast.FuncType:
+				// OnRetry func(attempt int) bool
+				if s.funcFields == nil {
+					s.funcFields = map[string]Func{}
+				}
+				s.funcFields[name] = newFunc(source, ft, imports)
+			}
+			s.fields[name] = source.translateType(t, imports)
+			if s.fieldPos == nil {
+				s.fieldPos = map[string]token.Position{}
+			}
+			s.fieldPos[name] = source.fs.Position(n.Pos())
+			if f.Tag != nil {
+				if s.tags == nil {
+					s.tags = map[string]string{}
+				}
+				s.tags[name] = f.Tag.Value
 			}
-			s.fields[*n] = source.translateType(t, imports)
 		}
-	})
+	}
 	sort.Strings(s.AnonymousFields)
-	return s
+}
+
+// anonymousStruct returns the anonymous struct type within the slice, array, pointer and map
+// types of x e.g. the struct of "map[string][]*struct{...}". It returns x when x has no such struct.
+func anonymousStruct(x ast.Expr) ast.Expr {
+	elem := x
+	for {
+		switch t := elem.(type) {
+		case *ast.ArrayType:
+			elem = t.Elt
+		case *ast.MapType:
+			elem = t.Value
+		case *ast.StarExpr:
+			elem = t.X
+		case *ast.StructType:
+			return t
+		default:
+			return x
+		}
+	}
 }
 
 func (s Struct) Exported() bool {
@@ -637,7 +906,7 @@ func parseAndMakeTypeTokens(val string) []ReviewToken {
 	now := ""
 	for _, r := range val {
 		switch s := string(r); s {
-		case "*", "[", "]", " ", "(", ")", "{", "}", ",":
+		case "*", "[", "]", " ", "(", ")", "{", "}", ",", "~", "|":
 			if now != "" {
 				toks = append(toks, makeTypeSectionToken(now))
 				now = ""